@@ -1,6 +1,10 @@
 package pagerduty
 
-import "context"
+import (
+	"context"
+	"errors"
+	"net/http"
+)
 
 // ListAbilityResponse is the response when calling the ListAbility API endpoint.
 type ListAbilityResponse struct {
@@ -9,7 +13,12 @@ type ListAbilityResponse struct {
 
 // ListAbilities lists all abilities on your account.
 func (c *Client) ListAbilities() (*ListAbilityResponse, error) {
-	resp, err := c.get(context.TODO(), "/abilities")
+	return c.ListAbilitiesWithContext(context.TODO())
+}
+
+// ListAbilitiesWithContext lists all abilities on your account.
+func (c *Client) ListAbilitiesWithContext(ctx context.Context) (*ListAbilityResponse, error) {
+	resp, err := c.get(ctx, "/abilities")
 	if err != nil {
 		return nil, err
 	}
@@ -19,6 +28,28 @@ func (c *Client) ListAbilities() (*ListAbilityResponse, error) {
 
 // TestAbility Check if your account has the given ability.
 func (c *Client) TestAbility(ability string) error {
-	_, err := c.get(context.TODO(), "/abilities/"+ability)
+	return c.TestAbilityWithContext(context.TODO(), ability)
+}
+
+// TestAbilityWithContext Check if your account has the given ability.
+func (c *Client) TestAbilityWithContext(ctx context.Context, ability string) error {
+	_, err := c.get(ctx, "/abilities/"+ability)
 	return err
 }
+
+// HasAbility reports whether your account has the given ability. Unlike
+// TestAbility, an account that lacks the ability is not an error: the API
+// answers that with a 402 Payment Required, which is reported as false.
+func (c *Client) HasAbility(ctx context.Context, ability string) (bool, error) {
+	err := c.TestAbilityWithContext(ctx, ability)
+	if err == nil {
+		return true, nil
+	}
+
+	var aerr APIError
+	if errors.As(err, &aerr) && aerr.StatusCode == http.StatusPaymentRequired {
+		return false, nil
+	}
+
+	return false, err
+}
@@ -1,6 +1,7 @@
 package pagerduty
 
 import (
+	"context"
 	"net/http"
 	"testing"
 )
@@ -73,3 +74,40 @@ func TestAbility_TestAbilityFailure(t *testing.T) {
 		t.Fatal("expected error; got nil")
 	}
 }
+
+func TestAbility_HasAbility(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/abilities/sso", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/abilities/teams", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.WriteHeader(http.StatusPaymentRequired)
+	})
+	mux.HandleFunc("/abilities/forbidden", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+	ctx := context.Background()
+
+	ok, err := client.HasAbility(ctx, "sso")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, true, ok)
+
+	ok, err = client.HasAbility(ctx, "teams")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, false, ok)
+
+	if _, err := client.HasAbility(ctx, "forbidden"); err == nil {
+		t.Fatal("expected error; got nil")
+	}
+}
@@ -22,6 +22,11 @@ type ListAddonOptions struct {
 	Includes   []string `url:"include,omitempty,brackets"`
 	ServiceIDs []string `url:"service_ids,omitempty,brackets"`
 	Filter     string   `url:"filter,omitempty"`
+
+	// ListAll makes ListAddons return every add-on in a single response, with
+	// Limit as the page size. Offset is ignored, and ListAll isn't sent to the
+	// API.
+	ListAll bool `url:"-"`
 }
 
 // ListAddonResponse is the response when calling the ListAddons API endpoint.
@@ -32,6 +37,22 @@ type ListAddonResponse struct {
 
 // ListAddons lists all of the add-ons installed on your account.
 func (c *Client) ListAddons(o ListAddonOptions) (*ListAddonResponse, error) {
+	if o.ListAll {
+		var all ListAddonResponse
+		responseHandler := func(response *http.Response) (APIListObject, error) {
+			var result ListAddonResponse
+			if err := c.decodeJSON(response, &result); err != nil {
+				return APIListObject{}, err
+			}
+			all.Addons = append(all.Addons, result.Addons...)
+			return result.APIListObject, nil
+		}
+		if err := c.pagedGetAll(context.TODO(), "/addons", o, responseHandler); err != nil {
+			return nil, err
+		}
+		return &all, nil
+	}
+
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
@@ -46,9 +67,7 @@ func (c *Client) ListAddons(o ListAddonOptions) (*ListAddonResponse, error) {
 
 // InstallAddon installs an add-on for your account.
 func (c *Client) InstallAddon(a Addon) (*Addon, error) {
-	data := make(map[string]Addon)
-	data["addon"] = a
-	resp, err := c.post(context.TODO(), "/addons", data, nil)
+	resp, err := c.post(context.TODO(), "/addons", wrapBody("addon", a), nil)
 	defer resp.Body.Close() // TODO(theckman): validate that this is safe
 	if err != nil {
 		return nil, err
@@ -76,9 +95,7 @@ func (c *Client) GetAddon(id string) (*Addon, error) {
 
 // UpdateAddon updates an existing add-on.
 func (c *Client) UpdateAddon(id string, a Addon) (*Addon, error) {
-	v := make(map[string]Addon)
-	v["addon"] = a
-	resp, err := c.put(context.TODO(), "/addons/"+id, v, nil)
+	resp, err := c.put(context.TODO(), "/addons/"+id, wrapBody("addon", a), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,157 @@
+package pagerduty
+
+import (
+	"context"
+)
+
+// The values accepted by the AggregateUnit field of AnalyticsRequest.
+const (
+	AnalyticsAggregateUnitDay   = "day"
+	AnalyticsAggregateUnitWeek  = "week"
+	AnalyticsAggregateUnitMonth = "month"
+)
+
+// analyticsEarlyAccess is sent as the X-EARLY-ACCESS header of every analytics
+// request, as the endpoints are still behind an early access flag.
+const analyticsEarlyAccess = "analytics-v2"
+
+// AnalyticsRequest is the data structure used when calling the
+// GetAggregatedIncidentData and GetRawIncidentData API endpoints.
+type AnalyticsRequest struct {
+	Filters *AnalyticsFilter `json:"filters,omitempty"`
+
+	// AggregateUnit groups the aggregated metrics by day, week or month. Leave
+	// it empty to aggregate over the whole time range. It's only used by
+	// GetAggregatedIncidentData.
+	AggregateUnit string `json:"aggregate_unit,omitempty"`
+	TimeZone      string `json:"time_zone,omitempty"`
+
+	// Limit, StartingAfter, Order and OrderBy page through the raw incident
+	// data and are only used by GetRawIncidentData.
+	Limit         uint   `json:"limit,omitempty"`
+	StartingAfter string `json:"starting_after,omitempty"`
+	Order         string `json:"order,omitempty"`
+	OrderBy       string `json:"order_by,omitempty"`
+}
+
+// AnalyticsFilter restricts the incidents an analytics request covers.
+type AnalyticsFilter struct {
+	CreatedAtStart string   `json:"created_at_start,omitempty"`
+	CreatedAtEnd   string   `json:"created_at_end,omitempty"`
+	Urgency        string   `json:"urgency,omitempty"`
+	Major          *bool    `json:"major,omitempty"`
+	ServiceIDs     []string `json:"service_ids,omitempty"`
+	TeamIDs        []string `json:"team_ids,omitempty"`
+	PriorityIDs    []string `json:"priority_ids,omitempty"`
+	PriorityNames  []string `json:"priority_names,omitempty"`
+}
+
+// AnalyticsAggregatedIncidentData is the set of incident metrics for a single
+// aggregation period, or for the whole time range when no AggregateUnit is
+// set. Times are in seconds.
+type AnalyticsAggregatedIncidentData struct {
+	RangeStart string `json:"range_start,omitempty"`
+
+	MeanAssignmentCount   float64 `json:"mean_assignment_count,omitempty"`
+	MeanEngagedSeconds    float64 `json:"mean_engaged_seconds,omitempty"`
+	MeanEngagedUserCount  float64 `json:"mean_engaged_user_count,omitempty"`
+	MeanSecondsToEngage   float64 `json:"mean_seconds_to_engage,omitempty"`
+	MeanSecondsToFirstAck float64 `json:"mean_seconds_to_first_ack,omitempty"`
+	MeanSecondsToMobilize float64 `json:"mean_seconds_to_mobilize,omitempty"`
+	MeanSecondsToResolve  float64 `json:"mean_seconds_to_resolve,omitempty"`
+
+	TotalBusinessHourInterruptions int     `json:"total_business_hour_interruptions,omitempty"`
+	TotalEngagedSeconds            int     `json:"total_engaged_seconds,omitempty"`
+	TotalEscalationCount           int     `json:"total_escalation_count,omitempty"`
+	TotalIncidentCount             int     `json:"total_incident_count,omitempty"`
+	TotalMajorIncidentCount        int     `json:"total_major_incident_count,omitempty"`
+	TotalOffHourInterruptions      int     `json:"total_off_hour_interruptions,omitempty"`
+	TotalSleepHourInterruptions    int     `json:"total_sleep_hour_interruptions,omitempty"`
+	TotalSnoozedSeconds            int     `json:"total_snoozed_seconds,omitempty"`
+	UpTimePct                      float64 `json:"up_time_pct,omitempty"`
+}
+
+// AnalyticsAggregatedIncidentResponse is the data structure returned from
+// calling the GetAggregatedIncidentData API endpoint.
+type AnalyticsAggregatedIncidentResponse struct {
+	Data          []AnalyticsAggregatedIncidentData `json:"data"`
+	Filters       *AnalyticsFilter                  `json:"filters,omitempty"`
+	AggregateUnit string                            `json:"aggregate_unit,omitempty"`
+	TimeZone      string                            `json:"time_zone,omitempty"`
+}
+
+// AnalyticsRawIncident is the metrics of a single incident. Times are in
+// seconds.
+type AnalyticsRawIncident struct {
+	ID             string `json:"id"`
+	IncidentNumber uint   `json:"incident_number,omitempty"`
+	Description    string `json:"description,omitempty"`
+	CreatedAt      string `json:"created_at,omitempty"`
+	ResolvedAt     string `json:"resolved_at,omitempty"`
+	Urgency        string `json:"urgency,omitempty"`
+	Major          bool   `json:"major,omitempty"`
+	ServiceID      string `json:"service_id,omitempty"`
+	ServiceName    string `json:"service_name,omitempty"`
+	TeamID         string `json:"team_id,omitempty"`
+	TeamName       string `json:"team_name,omitempty"`
+	PriorityID     string `json:"priority_id,omitempty"`
+	PriorityName   string `json:"priority_name,omitempty"`
+
+	SecondsToFirstAck         int `json:"seconds_to_first_ack,omitempty"`
+	SecondsToEngage           int `json:"seconds_to_engage,omitempty"`
+	SecondsToMobilize         int `json:"seconds_to_mobilize,omitempty"`
+	SecondsToResolve          int `json:"seconds_to_resolve,omitempty"`
+	EngagedSeconds            int `json:"engaged_seconds,omitempty"`
+	EngagedUserCount          int `json:"engaged_user_count,omitempty"`
+	EscalationCount           int `json:"escalation_count,omitempty"`
+	AssignmentCount           int `json:"assignment_count,omitempty"`
+	BusinessHourInterruptions int `json:"business_hour_interruptions,omitempty"`
+	OffHourInterruptions      int `json:"off_hour_interruptions,omitempty"`
+	SleepHourInterruptions    int `json:"sleep_hour_interruptions,omitempty"`
+	SnoozedSeconds            int `json:"snoozed_seconds,omitempty"`
+}
+
+// AnalyticsRawIncidentsResponse is the data structure returned from calling
+// the GetRawIncidentData API endpoint. To get the next page set the request's
+// StartingAfter to Last while More is true.
+type AnalyticsRawIncidentsResponse struct {
+	Data    []AnalyticsRawIncident `json:"data"`
+	Filters *AnalyticsFilter       `json:"filters,omitempty"`
+	First   string                 `json:"first,omitempty"`
+	Last    string                 `json:"last,omitempty"`
+	Limit   uint                   `json:"limit,omitempty"`
+	More    bool                   `json:"more,omitempty"`
+}
+
+// GetAggregatedIncidentData gets the incident metrics, such as the mean time
+// to acknowledge and resolve, aggregated over the incidents matching the
+// request's filters.
+func (c *Client) GetAggregatedIncidentData(ctx context.Context, req AnalyticsRequest) (*AnalyticsAggregatedIncidentResponse, error) {
+	resp, err := c.post(ctx, "/analytics/metrics/incidents/all", req, map[string]string{"X-EARLY-ACCESS": analyticsEarlyAccess})
+	if err != nil {
+		return nil, err
+	}
+
+	var result AnalyticsAggregatedIncidentResponse
+	if err := c.decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetRawIncidentData gets a page of the metrics of every incident matching the
+// request's filters.
+func (c *Client) GetRawIncidentData(ctx context.Context, req AnalyticsRequest) (*AnalyticsRawIncidentsResponse, error) {
+	resp, err := c.post(ctx, "/analytics/raw/incidents", req, map[string]string{"X-EARLY-ACCESS": analyticsEarlyAccess})
+	if err != nil {
+		return nil, err
+	}
+
+	var result AnalyticsRawIncidentsResponse
+	if err := c.decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
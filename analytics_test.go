@@ -0,0 +1,93 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// Get Aggregated Incident Data
+func TestAnalytics_GetAggregatedIncidentData(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/analytics/metrics/incidents/all", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testEqual(t, analyticsEarlyAccess, r.Header.Get("X-EARLY-ACCESS"))
+
+		var got AnalyticsRequest
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		testEqual(t, AnalyticsAggregateUnitWeek, got.AggregateUnit)
+		testEqual(t, []string{"PSVC1"}, got.Filters.ServiceIDs)
+		testEqual(t, "high", got.Filters.Urgency)
+
+		w.Write([]byte(`{"data": [{"range_start": "2025-01-06T00:00:00Z", "mean_seconds_to_first_ack": 120.5, "mean_seconds_to_resolve": 3600, "total_incident_count": 4}], "aggregate_unit": "week"}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+	req := AnalyticsRequest{
+		Filters: &AnalyticsFilter{
+			CreatedAtStart: "2025-01-06T00:00:00Z",
+			CreatedAtEnd:   "2025-01-13T00:00:00Z",
+			Urgency:        "high",
+			ServiceIDs:     []string{"PSVC1"},
+		},
+		AggregateUnit: AnalyticsAggregateUnitWeek,
+	}
+
+	res, err := client.GetAggregatedIncidentData(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &AnalyticsAggregatedIncidentResponse{
+		Data: []AnalyticsAggregatedIncidentData{
+			{
+				RangeStart:            "2025-01-06T00:00:00Z",
+				MeanSecondsToFirstAck: 120.5,
+				MeanSecondsToResolve:  3600,
+				TotalIncidentCount:    4,
+			},
+		},
+		AggregateUnit: AnalyticsAggregateUnitWeek,
+	}
+	testEqual(t, want, res)
+}
+
+// Get Raw Incident Data
+func TestAnalytics_GetRawIncidentData(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/analytics/raw/incidents", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var got AnalyticsRequest
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		testEqual(t, "PINC0", got.StartingAfter)
+
+		w.Write([]byte(`{"data": [{"id": "PINC1", "service_id": "PSVC1", "seconds_to_first_ack": 60, "seconds_to_resolve": 600}], "last": "PINC1", "limit": 1, "more": true}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.GetRawIncidentData(context.Background(), AnalyticsRequest{Limit: 1, StartingAfter: "PINC0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &AnalyticsRawIncidentsResponse{
+		Data: []AnalyticsRawIncident{
+			{ID: "PINC1", ServiceID: "PSVC1", SecondsToFirstAck: 60, SecondsToResolve: 600},
+		},
+		Last:  "PINC1",
+		Limit: 1,
+		More:  true,
+	}
+	testEqual(t, want, res)
+}
@@ -0,0 +1,142 @@
+package pagerduty
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/go-querystring/query"
+)
+
+// AuditRecord is a record of a change made to a resource in the account.
+type AuditRecord struct {
+	ID               string                       `json:"id,omitempty"`
+	Self             string                       `json:"self,omitempty"`
+	ExecutionTime    string                       `json:"execution_time,omitempty"`
+	ExecutionContext *AuditRecordExecutionContext `json:"execution_context,omitempty"`
+	Actors           []APIObject                  `json:"actors,omitempty"`
+	Method           AuditRecordMethod            `json:"method,omitempty"`
+	RootResource     APIObject                    `json:"root_resource,omitempty"`
+	Action           string                       `json:"action,omitempty"`
+	Details          *AuditRecordDetails          `json:"details,omitempty"`
+}
+
+// AuditRecordExecutionContext describes the request that made the change.
+type AuditRecordExecutionContext struct {
+	RequestID     string `json:"request_id,omitempty"`
+	RemoteAddress string `json:"remote_address,omitempty"`
+}
+
+// AuditRecordMethod describes how the actors were authenticated when they made
+// the change, such as with an API token or a browser session.
+type AuditRecordMethod struct {
+	Description    string `json:"description,omitempty"`
+	TruncatedToken string `json:"truncated_token,omitempty"`
+	Type           string `json:"type,omitempty"`
+}
+
+// AuditRecordDetails describes what changed on the resource.
+type AuditRecordDetails struct {
+	Resource APIObject `json:"resource,omitempty"`
+
+	// Fields are the fields of the resource whose values changed.
+	Fields []AuditRecordField `json:"fields,omitempty"`
+
+	// References are the references to other resources that were added or
+	// removed, such as a team's members.
+	References []AuditRecordReference `json:"references,omitempty"`
+}
+
+// AuditRecordField is a single field changed on a resource.
+type AuditRecordField struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Value       string `json:"value,omitempty"`
+	BeforeValue string `json:"before_value,omitempty"`
+}
+
+// AuditRecordReference is a change to the resources a resource refers to.
+type AuditRecordReference struct {
+	Name        string      `json:"name,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Added       []APIObject `json:"added,omitempty"`
+	Removed     []APIObject `json:"removed,omitempty"`
+}
+
+// ListAuditRecordsOptions is the data structure used when calling the
+// ListAuditRecords and ListServiceAuditRecords API endpoints.
+type ListAuditRecordsOptions struct {
+	// Limit is the number of records to ask for per page.
+	Limit uint `url:"limit,omitempty"`
+
+	// Cursor is where to start listing from. Leave it empty to start from the
+	// most recent record.
+	Cursor string `url:"cursor,omitempty"`
+
+	Since                string   `url:"since,omitempty"`
+	Until                string   `url:"until,omitempty"`
+	RootResourceTypes    []string `url:"root_resource_types,omitempty,brackets"`
+	ActorType            string   `url:"actor_type,omitempty"`
+	ActorID              string   `url:"actor_id,omitempty"`
+	MethodType           string   `url:"method_type,omitempty"`
+	MethodTruncatedToken string   `url:"method_truncated_token,omitempty"`
+	Actions              []string `url:"actions,omitempty,brackets"`
+}
+
+// ListAuditRecordsResponse is the data structure returned from calling the
+// ListAuditRecords and ListServiceAuditRecords API endpoints.
+type ListAuditRecordsResponse struct {
+	Records       []AuditRecord `json:"records"`
+	ResponseLimit uint          `json:"response_limit,omitempty"`
+	Limit         uint          `json:"limit,omitempty"`
+
+	// NextCursor is the cursor of the next page, or nil on the last page.
+	NextCursor *string `json:"next_cursor"`
+}
+
+// ListAuditRecords lists the account's audit records, most recent first,
+// following the cursor pagination used by the endpoint. To only list the
+// records of a specific service use ListServiceAuditRecords.
+func (c *Client) ListAuditRecords(ctx context.Context, o ListAuditRecordsOptions) ([]AuditRecord, error) {
+	return c.listAuditRecords(ctx, "/audit/records", o)
+}
+
+// ListServiceAuditRecords lists the audit records of a service, most recent
+// first, following the cursor pagination used by the endpoint. The
+// RootResourceTypes option doesn't apply to this endpoint.
+func (c *Client) ListServiceAuditRecords(ctx context.Context, serviceID string, o ListAuditRecordsOptions) ([]AuditRecord, error) {
+	o.RootResourceTypes = nil
+	return c.listAuditRecords(ctx, "/services/"+serviceID+"/audit/records", o)
+}
+
+func (c *Client) listAuditRecords(ctx context.Context, path string, o ListAuditRecordsOptions) ([]AuditRecord, error) {
+	records := make([]AuditRecord, 0)
+
+	// the cursor is set on each page by pagedGetCursor
+	cursor := o.Cursor
+	o.Cursor = ""
+
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	responseHandler := func(response *http.Response) (string, error) {
+		var result ListAuditRecordsResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return "", err
+		}
+
+		records = append(records, result.Records...)
+
+		if result.NextCursor == nil {
+			return "", nil
+		}
+		return *result.NextCursor, nil
+	}
+
+	if err := c.pagedGetCursor(ctx, path+"?"+v.Encode(), cursor, responseHandler); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
@@ -0,0 +1,94 @@
+package pagerduty
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+// List Audit Records
+func TestAuditRecords_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/audit/records", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, []string{"services"}, r.URL.Query()["root_resource_types[]"])
+
+		switch cursor := r.URL.Query().Get("cursor"); cursor {
+		case "":
+			w.Write([]byte(`{"records": [{"id": "R1", "action": "create", "root_resource": {"id": "PSVC1", "type": "service_reference"}}], "limit": 1, "next_cursor": "abc"}`))
+		case "abc":
+			w.Write([]byte(`{"records": [{"id": "R2", "action": "update", "root_resource": {"id": "PSVC1", "type": "service_reference"}}], "limit": 1, "next_cursor": null}`))
+		default:
+			t.Errorf("unexpected cursor %q", cursor)
+		}
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.ListAuditRecords(context.Background(), ListAuditRecordsOptions{RootResourceTypes: []string{"services"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root := APIObject{ID: "PSVC1", Type: "service_reference"}
+	want := []AuditRecord{
+		{ID: "R1", Action: "create", RootResource: root},
+		{ID: "R2", Action: "update", RootResource: root},
+	}
+
+	testEqual(t, want, res)
+}
+
+// List Service Audit Records
+func TestAuditRecords_ListService(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/PSVC1/audit/records", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Write([]byte(`{
+			"records": [{
+				"id": "R1",
+				"execution_time": "2020-06-04T15:25:11.530Z",
+				"execution_context": {"request_id": "req1", "remote_address": "10.0.0.1"},
+				"actors": [{"id": "PUSER1", "type": "user_reference", "summary": "Jane"}],
+				"method": {"type": "api_token", "truncated_token": "3xyz"},
+				"root_resource": {"id": "PSVC1", "type": "service_reference"},
+				"action": "update",
+				"details": {
+					"resource": {"id": "PSVC1", "type": "service_reference"},
+					"fields": [{"name": "name", "value": "new", "before_value": "old"}]
+				}
+			}],
+			"next_cursor": null
+		}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.ListServiceAuditRecords(context.Background(), "PSVC1", ListAuditRecordsOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service := APIObject{ID: "PSVC1", Type: "service_reference"}
+	want := []AuditRecord{
+		{
+			ID:               "R1",
+			ExecutionTime:    "2020-06-04T15:25:11.530Z",
+			ExecutionContext: &AuditRecordExecutionContext{RequestID: "req1", RemoteAddress: "10.0.0.1"},
+			Actors:           []APIObject{{ID: "PUSER1", Type: "user_reference", Summary: "Jane"}},
+			Method:           AuditRecordMethod{Type: "api_token", TruncatedToken: "3xyz"},
+			RootResource:     service,
+			Action:           "update",
+			Details: &AuditRecordDetails{
+				Resource: service,
+				Fields:   []AuditRecordField{{Name: "name", Value: "new", BeforeValue: "old"}},
+			},
+		},
+	}
+
+	testEqual(t, want, res)
+}
@@ -49,6 +49,11 @@ type ListBusinessServiceOptions struct {
 
 // ListBusinessServices lists existing business services.
 func (c *Client) ListBusinessServices(o ListBusinessServiceOptions) (*ListBusinessServicesResponse, error) {
+	return c.ListBusinessServicesWithContext(context.TODO(), o)
+}
+
+// ListBusinessServicesWithContext lists existing business services.
+func (c *Client) ListBusinessServicesWithContext(ctx context.Context, o ListBusinessServiceOptions) (*ListBusinessServicesResponse, error) {
 	queryParms, err := query.Values(o)
 	if err != nil {
 		return nil, err
@@ -76,7 +81,7 @@ func (c *Client) ListBusinessServices(o ListBusinessServiceOptions) (*ListBusine
 	}
 
 	// Make call to get all pages associated with the base endpoint.
-	if err := c.pagedGet(context.TODO(), "/business_services"+queryParms.Encode(), responseHandler); err != nil {
+	if err := c.pagedGet(ctx, "/business_services?"+queryParms.Encode(), responseHandler); err != nil {
 		return nil, err
 	}
 	businessServiceResponse.BusinessServices = businessServices
@@ -86,31 +91,47 @@ func (c *Client) ListBusinessServices(o ListBusinessServiceOptions) (*ListBusine
 
 // CreateBusinessService creates a new business service.
 func (c *Client) CreateBusinessService(b *BusinessService) (*BusinessService, *http.Response, error) {
-	data := make(map[string]*BusinessService)
-	data["business_service"] = b
-	resp, err := c.post(context.TODO(), "/business_services", data, nil)
+	return c.CreateBusinessServiceWithContext(context.TODO(), b)
+}
+
+// CreateBusinessServiceWithContext creates a new business service.
+func (c *Client) CreateBusinessServiceWithContext(ctx context.Context, b *BusinessService) (*BusinessService, *http.Response, error) {
+	resp, err := c.post(ctx, "/business_services", wrapBody("business_service", b), nil)
 	return getBusinessServiceFromResponse(c, resp, err)
 }
 
 // GetBusinessService gets details about a business service.
 func (c *Client) GetBusinessService(ID string) (*BusinessService, *http.Response, error) {
-	resp, err := c.get(context.TODO(), "/business_services/"+ID)
+	return c.GetBusinessServiceWithContext(context.TODO(), ID)
+}
+
+// GetBusinessServiceWithContext gets details about a business service.
+func (c *Client) GetBusinessServiceWithContext(ctx context.Context, ID string) (*BusinessService, *http.Response, error) {
+	resp, err := c.get(ctx, "/business_services/"+ID)
 	return getBusinessServiceFromResponse(c, resp, err)
 }
 
 // DeleteBusinessService deletes a business_service.
 func (c *Client) DeleteBusinessService(ID string) error {
-	_, err := c.delete(context.TODO(), "/business_services/"+ID)
+	return c.DeleteBusinessServiceWithContext(context.TODO(), ID)
+}
+
+// DeleteBusinessServiceWithContext deletes a business_service.
+func (c *Client) DeleteBusinessServiceWithContext(ctx context.Context, ID string) error {
+	_, err := c.delete(ctx, "/business_services/"+ID)
 	return err
 }
 
 // UpdateBusinessService updates a business_service.
 func (c *Client) UpdateBusinessService(b *BusinessService) (*BusinessService, *http.Response, error) {
-	v := make(map[string]*BusinessService)
+	return c.UpdateBusinessServiceWithContext(context.TODO(), b)
+}
+
+// UpdateBusinessServiceWithContext updates a business_service.
+func (c *Client) UpdateBusinessServiceWithContext(ctx context.Context, b *BusinessService) (*BusinessService, *http.Response, error) {
 	id := b.ID
 	b.ID = ""
-	v["business_service"] = b
-	resp, err := c.put(context.TODO(), "/business_services/"+id, v, nil)
+	resp, err := c.put(ctx, "/business_services/"+id, wrapBody("business_service", b), nil)
 	return getBusinessServiceFromResponse(c, resp, err)
 }
 
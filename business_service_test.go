@@ -1,6 +1,7 @@
 package pagerduty
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
@@ -37,6 +38,40 @@ func TestBusinessService_List(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+// List BusinessServices with a page limit
+func TestBusinessService_ListWithContextLimit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/business_services", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "1", r.URL.Query().Get("limit"))
+		switch offset := r.URL.Query().Get("offset"); offset {
+		case "0":
+			w.Write([]byte(`{"business_services": [{"id": "1", "point_of_contact": "#ops"}], "limit": 1, "offset": 0, "more": true}`))
+		case "1":
+			w.Write([]byte(`{"business_services": [{"id": "2", "team": {"id": "PTEAM1"}}], "limit": 1, "offset": 1, "more": false}`))
+		default:
+			t.Errorf("unexpected offset %q", offset)
+		}
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+	opts := ListBusinessServiceOptions{
+		APIListObject: APIListObject{Limit: 1},
+	}
+	res, err := client.ListBusinessServicesWithContext(context.Background(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*BusinessService{
+		{ID: "1", PointOfContact: "#ops"},
+		{ID: "2", Team: &BusinessServiceTeam{ID: "PTEAM1"}},
+	}
+	testEqual(t, want, res.BusinessServices)
+}
+
 // Create BusinessService
 func TestBusinessService_Create(t *testing.T) {
 	setup()
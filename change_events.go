@@ -6,6 +6,9 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"time"
+
+	"github.com/google/go-querystring/query"
 )
 
 const changeEventPath = "/v2/change/enqueue"
@@ -45,34 +48,99 @@ type ChangeEventResponse struct {
 // The v2EventsAPIEndpoint parameter must be set on the client
 // Documentation can be found at https://developer.pagerduty.com/docs/events-api-v2/send-change-events
 func (c *Client) CreateChangeEvent(e ChangeEvent) (*ChangeEventResponse, error) {
+	return c.CreateChangeEventWithContext(context.TODO(), e)
+}
+
+// CreateChangeEventWithContext sends PagerDuty a single ChangeEvent to record.
+// If the Events API rejects it, the error is an EventsAPIError.
+func (c *Client) CreateChangeEventWithContext(ctx context.Context, e ChangeEvent) (*ChangeEventResponse, error) {
 	if c.v2EventsAPIEndpoint == "" {
 		return nil, errors.New("v2EventsAPIEndpoint field must be set on Client")
 	}
 
-	headers := make(map[string]string)
-
 	data, err := json.Marshal(e)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.doWithEndpoint(
-		context.TODO(),
-		c.v2EventsAPIEndpoint,
-		http.MethodPost,
-		changeEventPath,
-		false,
-		bytes.NewBuffer(data),
-		headers,
-	)
+	resp, err := c.doEventsRequest(ctx, changeEventPath, bytes.NewBuffer(data))
 	if err != nil {
 		return nil, err
 	}
 
 	var eventResponse ChangeEventResponse
-	if err := json.NewDecoder(resp.Body).Decode(&eventResponse); err != nil {
+	if err := c.decodeJSON(resp, &eventResponse); err != nil {
 		return nil, err
 	}
 
 	return &eventResponse, nil
 }
+
+// ServiceChangeEvent is a change event as recorded by PagerDuty and returned
+// by the REST API, as opposed to ChangeEvent which is what gets sent to the
+// Events API.
+type ServiceChangeEvent struct {
+	APIObject
+	Summary       string                 `json:"summary,omitempty"`
+	Source        string                 `json:"source,omitempty"`
+	Timestamp     string                 `json:"timestamp,omitempty"`
+	Links         []ChangeEventLink      `json:"links,omitempty"`
+	CustomDetails map[string]interface{} `json:"custom_details,omitempty"`
+	Services      []APIObject            `json:"services,omitempty"`
+
+	// Integration is the integration that received the change event.
+	Integration APIObject `json:"integration,omitempty"`
+}
+
+// ListServiceChangeEventsOptions is the data structure used when calling the ListServiceChangeEvents API endpoint.
+type ListServiceChangeEventsOptions struct {
+	APIListObject
+	TeamIDs        []string `url:"team_ids,omitempty,brackets"`
+	IntegrationIDs []string `url:"integration_ids,omitempty,brackets"`
+
+	// Since and Until restrict the change events to those that happened in
+	// that time range. They are sent as RFC 3339 timestamps, and are ignored
+	// when zero.
+	Since time.Time `url:"since,omitempty"`
+	Until time.Time `url:"until,omitempty"`
+}
+
+// ListServiceChangeEventsResponse is the data structure returned from calling the ListServiceChangeEvents API endpoint.
+type ListServiceChangeEventsResponse struct {
+	APIListObject
+	ChangeEvents []ServiceChangeEvent `json:"change_events"`
+}
+
+// ListServiceChangeEvents lists all of the change events recorded for a
+// service, following pagination.
+func (c *Client) ListServiceChangeEvents(ctx context.Context, serviceID string, o ListServiceChangeEventsOptions) ([]ServiceChangeEvent, error) {
+	o.Offset = 0
+
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]ServiceChangeEvent, 0)
+
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		var result ListServiceChangeEventsResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return APIListObject{}, err
+		}
+
+		events = append(events, result.ChangeEvents...)
+
+		return APIListObject{
+			More:   result.More,
+			Offset: result.Offset,
+			Limit:  result.Limit,
+		}, nil
+	}
+
+	if err := c.pagedGet(ctx, "/services/"+serviceID+"/change_events?"+v.Encode(), responseHandler); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
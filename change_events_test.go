@@ -1,9 +1,12 @@
 package pagerduty
 
 import (
+	"context"
+	"errors"
 	"io/ioutil"
 	"net/http"
 	"testing"
+	"time"
 )
 
 const (
@@ -113,3 +116,105 @@ func TestChangeEvent_CreateWithPayloadVerification(t *testing.T) {
 	_, _ = client.CreateChangeEvent(ce)
 
 }
+
+func TestChangeEvent_ListServiceChangeEvents(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/1/change_events", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Write([]byte(`{"change_events": [{"id": "CE1", "type": "change_event", "summary": "Deploy", "source": "ci", "timestamp": "2020-10-19T03:06:16Z", "links": [{"href": "https://example.com/build/2", "text": "Build"}], "integration": {"id": "I1", "type": "inbound_integration_reference"}}], "more": false}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.ListServiceChangeEvents(context.Background(), "1", ListServiceChangeEventsOptions{})
+
+	want := []ServiceChangeEvent{
+		{
+			APIObject: APIObject{
+				ID:   "CE1",
+				Type: "change_event",
+			},
+			Summary:   "Deploy",
+			Source:    "ci",
+			Timestamp: "2020-10-19T03:06:16Z",
+			Links:     []ChangeEventLink{{Href: "https://example.com/build/2", Text: "Build"}},
+			Integration: APIObject{
+				ID:   "I1",
+				Type: "inbound_integration_reference",
+			},
+		},
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, want, res)
+}
+
+func TestChangeEvent_ListServiceChangeEventsFilters(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/1/change_events", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		q := r.URL.Query()
+		testEqual(t, "2020-10-18T03:00:00Z", q.Get("since"))
+		testEqual(t, "", q.Get("until"))
+		testEqual(t, []string{"I1", "I2"}, q["integration_ids[]"])
+
+		w.Write([]byte(`{"change_events": [], "more": false}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	o := ListServiceChangeEventsOptions{
+		IntegrationIDs: []string{"I1", "I2"},
+		Since:          time.Date(2020, 10, 18, 3, 0, 0, 0, time.UTC),
+	}
+
+	if _, err := client.ListServiceChangeEvents(context.Background(), "1", o); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestChangeEvent_CreateWithContextEventsAPIError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/change/enqueue", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status": "invalid event", "message": "Event object is invalid", "errors": ["'routing_key' is missing or blank"]}`))
+	})
+
+	var client = &Client{
+		v2EventsAPIEndpoint: server.URL,
+		apiEndpoint:         server.URL,
+		authToken:           "foo",
+		HTTPClient:          defaultHTTPClient,
+	}
+
+	_, err := client.CreateChangeEventWithContext(context.Background(), ChangeEvent{})
+
+	var eerr EventsAPIError
+	if !errors.As(err, &eerr) {
+		t.Fatalf("CreateChangeEventWithContext() error = %v, want an EventsAPIError", err)
+	}
+
+	want := EventsAPIError{
+		StatusCode: http.StatusBadRequest,
+		Status:     "invalid event",
+		Message:    "Event object is invalid",
+		Errors:     []string{"'routing_key' is missing or blank"},
+	}
+	testEqual(t, want, eerr)
+
+	const wantErr = "Events API request failed with status code 400, status: invalid event, message: Event object is invalid ('routing_key' is missing or blank)"
+	if got := err.Error(); got != wantErr {
+		t.Errorf("err.Error() = %q, want %q", got, wantErr)
+	}
+}
@@ -4,14 +4,21 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net"
 	"net/http"
+	"net/url"
 	"path"
 	"runtime"
+	"sort"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/google/go-querystring/query"
 )
 
 const (
@@ -19,6 +26,13 @@ const (
 	v2EventsAPIEndpoint = "https://events.pagerduty.com"
 )
 
+// The endpoints of accounts in the EU service region, to be passed to
+// WithAPIEndpoint and WithV2EventsAPIEndpoint.
+const (
+	EUAPIEndpoint         = "https://api.eu.pagerduty.com"
+	EUV2EventsAPIEndpoint = "https://events.eu.pagerduty.com"
+)
+
 // The type of authentication to use with the API client
 type authType int
 
@@ -157,6 +171,12 @@ func (a APIError) NotFound() bool {
 	return a.StatusCode == http.StatusNotFound || (a.APIError.Valid && a.APIError.ErrorObject.Code == 2100)
 }
 
+// Unauthorized returns whether the request was rejected because the API token
+// is invalid or has been revoked.
+func (a APIError) Unauthorized() bool {
+	return a.StatusCode == http.StatusUnauthorized
+}
+
 func newDefaultHTTPClient() *http.Client {
 	return &http.Client{
 		Transport: &http.Transport{
@@ -203,6 +223,28 @@ type Client struct {
 	// PagerDuty API. You can use either *http.Client here, or your own
 	// implementation.
 	HTTPClient HTTPClient
+
+	logger Logger
+
+	retryableMethods  map[string]bool
+	maxRetries        int
+	respectRetryAfter bool
+
+	batchConcurrency int
+
+	maxPages int
+
+	requestResponseHook RequestResponseHook
+
+	rateLimiter RateLimiter
+
+	prioritiesMu sync.Mutex
+	priorities   []PriorityProperty
+
+	defaultFrom      string
+	fromCurrentUser  bool
+	currentUserMu    sync.Mutex
+	currentUserEmail string
 }
 
 // NewClient creates an API client using an account/user API token
@@ -222,25 +264,169 @@ func NewClient(authToken string, options ...ClientOptions) *Client {
 	return &client
 }
 
-// NewOAuthClient creates an API client using an OAuth token
+// NewOAuthClient creates an API client using an OAuth token, which is sent as
+// a Bearer token rather than as an API token.
 func NewOAuthClient(authToken string, options ...ClientOptions) *Client {
-	return NewClient(authToken, WithOAuth())
+	return NewClient(authToken, append([]ClientOptions{WithOAuth()}, options...)...)
 }
 
 // ClientOptions allows for options to be passed into the Client for customization
 type ClientOptions func(*Client)
 
-// WithAPIEndpoint allows for a custom API endpoint to be passed into the the client
+// RequestOption customizes a single request, for the methods that accept
+// them.
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	headers map[string]string
+}
+
+// idempotencyKeyHeader is the request header set by WithIdempotencyKey.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// WithIdempotencyKey sets the Idempotency-Key header of a create request. The
+// PagerDuty REST API doesn't document this header, so don't rely on it to
+// de-duplicate a create that is sent again; it's only useful to proxies or
+// gateways in front of the API that honor it. Setting a key doesn't make the
+// request retryable: POST is only retried if WithRetryableMethods allows it.
+func WithIdempotencyKey(key string) RequestOption {
+	return func(o *requestOptions) {
+		o.headers[idempotencyKeyHeader] = key
+	}
+}
+
+// requestHeaders applies opts and returns the resulting request headers, or
+// nil if there are none.
+func requestHeaders(opts []RequestOption) map[string]string {
+	if len(opts) == 0 {
+		return nil
+	}
+
+	o := requestOptions{headers: make(map[string]string)}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o.headers
+}
+
+// WithAPIEndpoint allows for a custom API endpoint to be passed into the the
+// client, such as EUAPIEndpoint or a mock server. Trailing slashes are ignored.
 func WithAPIEndpoint(endpoint string) ClientOptions {
 	return func(c *Client) {
-		c.apiEndpoint = endpoint
+		c.apiEndpoint = strings.TrimRight(endpoint, "/")
 	}
 }
 
-// WithV2EventsAPIEndpoint allows for a custom V2 Events API endpoint to be passed into the client
+// WithV2EventsAPIEndpoint allows for a custom V2 Events API endpoint to be
+// passed into the client, such as EUV2EventsAPIEndpoint or a mock server.
+// Trailing slashes are ignored.
 func WithV2EventsAPIEndpoint(endpoint string) ClientOptions {
 	return func(c *Client) {
-		c.v2EventsAPIEndpoint = endpoint
+		c.v2EventsAPIEndpoint = strings.TrimRight(endpoint, "/")
+	}
+}
+
+// RateLimiter throttles the requests made by a client, see WithRateLimiter.
+// *rate.Limiter from golang.org/x/time/rate satisfies it.
+type RateLimiter interface {
+	// Wait blocks until the next request may be sent, or returns an error if
+	// it can't be, for example because ctx is done.
+	Wait(ctx context.Context) error
+}
+
+// WithRateLimiter makes the client wait on limiter before sending each
+// request, including retries and Events API requests, so that it stays under
+// the API's rate limits instead of reacting to 429 responses. A limiter can
+// be shared by several clients and goroutines. If Wait returns an error the
+// request isn't sent and the error is returned.
+func WithRateLimiter(limiter RateLimiter) ClientOptions {
+	return func(c *Client) {
+		c.rateLimiter = limiter
+	}
+}
+
+// WithDefaultFrom sets the email address sent as the From header by the
+// methods requiring one, such as CreateIncidentWithContext or
+// RunResponsePlay, when their from argument is empty. A non-empty from
+// argument still takes precedence.
+func WithDefaultFrom(email string) ClientOptions {
+	return func(c *Client) {
+		c.defaultFrom = email
+	}
+}
+
+// WithCurrentUserFrom makes the methods requiring a From header, such as
+// CreateIncidentWithContext, send the email address of the user behind the
+// token when their from argument is empty and no WithDefaultFrom address was
+// set. The address is looked up with CurrentUserEmail the first time it's
+// needed and then cached, so it only works with user-level API keys and OAuth
+// tokens.
+func WithCurrentUserFrom() ClientOptions {
+	return func(c *Client) {
+		c.fromCurrentUser = true
+	}
+}
+
+// Ping checks that the API can be reached and accepts the client's
+// credentials, by listing the account's abilities, which any valid token can
+// do. An invalid token is reported as an APIError whose Unauthorized method
+// returns true.
+func (c *Client) Ping(ctx context.Context) error {
+	resp, err := c.get(ctx, "/abilities")
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// ErrMaxPages is returned by the methods listing every page of a resource
+// when the listing was stopped by WithMaxPages before its last page.
+var ErrMaxPages = errors.New("stopped listing after the maximum number of pages")
+
+// WithMaxPages limits the methods listing every page of a resource to
+// fetching at most n pages, returning ErrMaxPages when there are more. Like
+// for a failed page, ListServicesPaginated and ListServiceRulesWithContext
+// also return what they listed so far. A limit of zero or less, the default,
+// fetches every page.
+func WithMaxPages(n int) ClientOptions {
+	return func(c *Client) {
+		c.maxPages = n
+	}
+}
+
+// pageLimitReached reports whether the listing should stop after the given
+// number of pages although more are available.
+func (c *Client) pageLimitReached(pages int) bool {
+	return c.maxPages > 0 && pages >= c.maxPages
+}
+
+// WithHTTPClient sets the HTTP client used to make requests, for example one
+// with a custom transport for a proxy or TLS configuration. A nil client
+// leaves the default in place. The context passed to each method is always
+// honored, so a request is abandoned as soon as the context is done even if
+// the client's Timeout hasn't been reached.
+func WithHTTPClient(hc *http.Client) ClientOptions {
+	return func(c *Client) {
+		if hc != nil {
+			c.HTTPClient = hc
+		}
+	}
+}
+
+// RequestResponseHook is called after every round trip to the API, including
+// each retry, with the request that was sent and the response or error that
+// came back. resp is nil if err is a transport error.
+type RequestResponseHook func(req *http.Request, resp *http.Response, err error)
+
+// WithRequestResponseHook registers a hook to observe the client's HTTP
+// traffic, for example to log the method, URL, and status of each request.
+// The hook may read the response body; it is buffered beforehand and restored
+// afterwards so the client can still decode it. The hook runs synchronously,
+// so it should be quick.
+func WithRequestResponseHook(hook RequestResponseHook) ClientOptions {
+	return func(c *Client) {
+		c.requestResponseHook = hook
 	}
 }
 
@@ -251,6 +437,12 @@ func WithOAuth() ClientOptions {
 	}
 }
 
+// wrapBody wraps v in a JSON object under rootKey, which is how the API
+// expects most request bodies to be shaped (e.g. {"service": {...}}).
+func wrapBody(rootKey string, v interface{}) map[string]interface{} {
+	return map[string]interface{}{rootKey: v}
+}
+
 func (c *Client) delete(ctx context.Context, path string) (*http.Response, error) {
 	return c.do(ctx, http.MethodDelete, path, nil, nil)
 }
@@ -280,6 +472,104 @@ func (c *Client) get(ctx context.Context, path string) (*http.Response, error) {
 
 // needed where pagerduty use a different endpoint for certain actions (eg: v2 events)
 func (c *Client) doWithEndpoint(ctx context.Context, endpoint, method, path string, authRequired bool, body io.Reader, headers map[string]string) (*http.Response, error) {
+	return c.doWithCheck(ctx, endpoint, method, path, authRequired, body, headers, c.checkResponse)
+}
+
+// doEventsRequest posts body to the Events API, whose errors are shaped
+// differently from the REST API's and are returned as an EventsAPIError.
+func (c *Client) doEventsRequest(ctx context.Context, path string, body io.Reader) (*http.Response, error) {
+	return c.doWithCheck(ctx, c.v2EventsAPIEndpoint, http.MethodPost, path, false, body, nil, c.checkEventsResponse)
+}
+
+// responseChecker turns the outcome of a round trip into an error if the
+// request failed.
+type responseChecker func(resp *http.Response, err error) (*http.Response, error)
+
+func (c *Client) doWithCheck(ctx context.Context, endpoint, method, path string, authRequired bool, body io.Reader, headers map[string]string, check responseChecker) (*http.Response, error) {
+	// buffer the body so that it can be sent again if the request is retried
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = ioutil.ReadAll(body); err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		if c.rateLimiter != nil {
+			if err := c.rateLimiter.Wait(ctx); err != nil {
+				return nil, fmt.Errorf("rate limiter: %w", err)
+			}
+		}
+
+		req, err := c.newRequest(ctx, endpoint, method, path, authRequired, data, headers)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.HTTPClient.Do(req)
+		if c.requestResponseHook != nil {
+			err = c.callRequestResponseHook(req, resp, err)
+		}
+		resp, err = check(resp, err)
+		if err == nil {
+			return resp, nil
+		}
+
+		c.log().Errorf("Error on the %s %s request: %v", method, path, err)
+
+		wait, retry := c.retryWait(req, resp, err, attempt)
+		if !retry {
+			if attempt > 1 {
+				err = fmt.Errorf("request failed after %d attempts: %w", attempt, err)
+			}
+			return resp, err
+		}
+
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+
+		c.log().Debugf("Retrying the %s %s request in %s (attempt %d)", method, path, wait, attempt+1)
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, fmt.Errorf("request canceled after %d attempts: %w", attempt, ctx.Err())
+		case <-t.C:
+		}
+	}
+}
+
+// callRequestResponseHook buffers the response body so the hook can't
+// consume it, then calls the hook. The returned error is err, unless the body
+// couldn't be read.
+func (c *Client) callRequestResponseHook(req *http.Request, resp *http.Response, err error) error {
+	if err != nil || resp == nil || resp.Body == nil {
+		c.requestResponseHook(req, resp, err)
+		return err
+	}
+
+	data, rErr := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if rErr != nil {
+		rErr = fmt.Errorf("failed to read response body: %w", rErr)
+	}
+
+	resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+	c.requestResponseHook(req, resp, rErr)
+	resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+
+	return rErr
+}
+
+func (c *Client) newRequest(ctx context.Context, endpoint, method, path string, authRequired bool, data []byte, headers map[string]string) (*http.Request, error) {
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+
 	req, err := http.NewRequestWithContext(ctx, method, endpoint+path, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build request: %w", err)
@@ -303,23 +593,87 @@ func (c *Client) doWithEndpoint(ctx context.Context, endpoint, method, path stri
 	req.Header.Set("User-Agent", "go-pagerduty/"+Version)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.HTTPClient.Do(req)
-	return c.checkResponse(resp, err)
+	return req, nil
 }
 
 func (c *Client) do(ctx context.Context, method, path string, body io.Reader, headers map[string]string) (*http.Response, error) {
 	return c.doWithEndpoint(ctx, c.apiEndpoint, method, path, true, body, headers)
 }
 
+// decodeErrorBodyLen is how much of a response body a DecodeError keeps.
+const decodeErrorBodyLen = 512
+
+// DecodeError is returned when a response body can't be decoded as the
+// expected JSON, such as when a proxy answers with an HTML error page.
+type DecodeError struct {
+	// StatusCode is the HTTP response status code.
+	StatusCode int
+
+	// Body is the start of the response body, at most 512 bytes of it, and
+	// Truncated reports whether the body was longer than that.
+	Body      string
+	Truncated bool
+
+	Err error
+}
+
+// Error satisfies the error interface.
+func (e DecodeError) Error() string {
+	var more string
+	if e.Truncated {
+		more = "..."
+	}
+	return fmt.Sprintf("failed to decode the response with status code %d: %v, body: %q%s", e.StatusCode, e.Err, e.Body, more)
+}
+
+// Unwrap returns the JSON decoding error.
+func (e DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// headBuffer keeps the first n bytes written to it and discards the rest.
+type headBuffer struct {
+	buf       []byte
+	n         int
+	truncated bool
+}
+
+func (b *headBuffer) Write(p []byte) (int, error) {
+	if room := b.n - len(b.buf); room < len(p) {
+		b.buf = append(b.buf, p[:room]...)
+		b.truncated = true
+	} else {
+		b.buf = append(b.buf, p...)
+	}
+	return len(p), nil
+}
+
 func (c *Client) decodeJSON(resp *http.Response, payload interface{}) error {
 	defer resp.Body.Close()
-	decoder := json.NewDecoder(resp.Body)
-	return decoder.Decode(payload)
+
+	// keep the start of the body to report it if decoding fails
+	head := &headBuffer{n: decodeErrorBodyLen}
+	decoder := json.NewDecoder(io.TeeReader(resp.Body, head))
+	if err := decoder.Decode(payload); err != nil {
+		// the decoder reads ahead, so the body may be longer than what it read
+		if !head.truncated {
+			_, _ = io.CopyN(head, resp.Body, int64(decodeErrorBodyLen-len(head.buf)+1))
+		}
+
+		return DecodeError{
+			StatusCode: resp.StatusCode,
+			Body:       string(head.buf),
+			Truncated:  head.truncated,
+			Err:        err,
+		}
+	}
+
+	return nil
 }
 
 func (c *Client) checkResponse(resp *http.Response, err error) (*http.Response, error) {
 	if err != nil {
-		return resp, fmt.Errorf("Error calling the API endpoint: %v", err)
+		return resp, fmt.Errorf("Error calling the API endpoint: %w", err)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
@@ -357,6 +711,92 @@ func (c *Client) getErrorFromResponse(resp *http.Response) APIError {
 	return document
 }
 
+// BatchError is returned by methods that perform several API calls on the
+// caller's behalf when one or more of those calls fail. Errors is keyed by the
+// index of the failed item in the input.
+type BatchError struct {
+	Errors map[int]error
+}
+
+// Error satisfies the error interface.
+func (b *BatchError) Error() string {
+	idx := make([]int, 0, len(b.Errors))
+	for i := range b.Errors {
+		idx = append(idx, i)
+	}
+	sort.Ints(idx)
+
+	msgs := make([]string, 0, len(idx))
+	for _, i := range idx {
+		msgs = append(msgs, fmt.Sprintf("item %d: %v", i, b.Errors[i]))
+	}
+
+	return fmt.Sprintf("%d batch operation(s) failed: %s", len(idx), strings.Join(msgs, "; "))
+}
+
+// defaultBatchConcurrency bounds the number of in-flight requests made by
+// runBatch, unless overridden with WithBatchConcurrency.
+const defaultBatchConcurrency = 10
+
+// WithBatchConcurrency sets how many requests the client's batch methods, like
+// CreateIntegrations and GetServicesByIDs, may have in flight at once. The
+// default is 10.
+func WithBatchConcurrency(n int) ClientOptions {
+	return func(c *Client) {
+		c.batchConcurrency = n
+	}
+}
+
+// runBatch calls fn for each index in [0, n) with a bounded number of calls
+// running at once. Every index is attempted; if any call fails the returned
+// error is a *BatchError describing each failure.
+func (c *Client) runBatch(ctx context.Context, n int, fn func(ctx context.Context, i int) error) error {
+	limit := c.batchConcurrency
+	if limit <= 0 {
+		limit = defaultBatchConcurrency
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs = make(map[int]error)
+		sem  = make(chan struct{}, limit)
+	)
+
+	for i := 0; i < n; i++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			mu.Lock()
+			errs[i] = ctx.Err()
+			mu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if err := fn(ctx, i); err != nil {
+				mu.Lock()
+				errs[i] = err
+				mu.Unlock()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return &BatchError{Errors: errs}
+	}
+
+	return nil
+}
+
 // Helper function to determine wither additional parameters should use ? or & to append args
 func getBasePrefix(basePath string) string {
 	if strings.Contains(path.Base(basePath), "?") {
@@ -372,6 +812,27 @@ func getBasePrefix(basePath string) string {
 // a specific slice. The responseHandler is responsible for closing the response.
 type responseHandler func(response *http.Response) (APIListObject, error)
 
+// PageError is returned by the methods that list every page of a resource
+// when one of the pages can't be fetched. Offset and Limit describe the page
+// that failed, so the listing can be resumed from it; Limit is zero if the
+// first page failed, as the API's default limit isn't known yet.
+type PageError struct {
+	Offset uint
+	Limit  uint
+	Err    error
+}
+
+func (e PageError) Error() string {
+	return fmt.Sprintf("failed to get the page at offset %d (limit %d): %v", e.Offset, e.Limit, e.Err)
+}
+
+// Unwrap returns the error that caused the page to fail.
+func (e PageError) Unwrap() error {
+	return e.Err
+}
+
+// pagedGet gets every page of basePath, passing each one to handler. If a page
+// fails the error is returned as a PageError.
 func (c *Client) pagedGet(ctx context.Context, basePath string, handler responseHandler) error {
 	// Indicates whether there are still additional pages associated with request.
 	var stillMore bool
@@ -379,24 +840,158 @@ func (c *Client) pagedGet(ctx context.Context, basePath string, handler response
 	// Offset to set for the next page request.
 	var nextOffset uint
 
+	// Limit of the last page, reported if the next one fails.
+	var limit uint
+
+	// Number of pages fetched, to stop at the WithMaxPages limit.
+	var pages int
+
 	basePrefix := getBasePrefix(basePath)
 	// While there are more pages, keep adjusting the offset to get all results.
 	for stillMore, nextOffset = true, 0; stillMore; {
+		if c.pageLimitReached(pages) {
+			return ErrMaxPages
+		}
+		pages++
+
 		response, err := c.do(ctx, http.MethodGet, fmt.Sprintf("%soffset=%d", basePrefix, nextOffset), nil, nil)
 		if err != nil {
-			return err
+			return PageError{Offset: nextOffset, Limit: limit, Err: err}
 		}
 
 		// Call handler to extract page information and execute additional necessary handling.
 		pageInfo, err := handler(response)
 		if err != nil {
-			return err
+			return PageError{Offset: nextOffset, Limit: limit, Err: err}
 		}
 
 		// Bump the offset as necessary and set whether more results exist.
 		nextOffset = pageInfo.Offset + pageInfo.Limit
+		limit = pageInfo.Limit
 		stillMore = pageInfo.More
 	}
 
 	return nil
 }
+
+// pagedGetAll gets every page of path for the list methods supporting a
+// ListAll option. o is encoded as the query, leaving out the offset, more and
+// total fields, which pagedGet manages.
+func (c *Client) pagedGetAll(ctx context.Context, path string, o interface{}, handler responseHandler) error {
+	v, err := query.Values(o)
+	if err != nil {
+		return err
+	}
+	v.Del("offset")
+	v.Del("more")
+	v.Del("total")
+
+	return c.pagedGet(ctx, path+"?"+v.Encode(), handler)
+}
+
+// pageDecoder decodes a page of a listing. It returns the page information,
+// and a function processing the page's items that is called while the next
+// page is being fetched.
+type pageDecoder func(response *http.Response) (APIListObject, func() error, error)
+
+// pagedGetPrefetch is like pagedGet, but fetches the next page while the
+// items of the current one are processed, so that slow processing and the
+// API's latency overlap. At most one page is fetched ahead.
+func (c *Client) pagedGetPrefetch(ctx context.Context, basePath string, decode pageDecoder) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type page struct {
+		response *http.Response
+		err      error
+	}
+
+	basePrefix := getBasePrefix(basePath)
+	fetch := func(offset uint) <-chan page {
+		ch := make(chan page, 1)
+		go func() {
+			response, err := c.do(ctx, http.MethodGet, fmt.Sprintf("%soffset=%d", basePrefix, offset), nil, nil)
+			ch <- page{response: response, err: err}
+		}()
+		return ch
+	}
+
+	var offset, limit uint
+	var pages int
+	for next := fetch(0); next != nil; {
+		p := <-next
+		next = nil
+		if p.err != nil {
+			return PageError{Offset: offset, Limit: limit, Err: p.err}
+		}
+
+		pageInfo, process, err := decode(p.response)
+		if err != nil {
+			return PageError{Offset: offset, Limit: limit, Err: err}
+		}
+
+		pages++
+		failedOffset, failedLimit := offset, limit
+		offset, limit = pageInfo.Offset+pageInfo.Limit, pageInfo.Limit
+		truncated := pageInfo.More && c.pageLimitReached(pages)
+		if pageInfo.More && !truncated {
+			next = fetch(offset)
+		}
+
+		if err := process(); err != nil {
+			if next != nil {
+				// stop the prefetch and close its response, if it arrived
+				cancel()
+				if p := <-next; p.response != nil {
+					_ = p.response.Body.Close()
+				}
+			}
+			return PageError{Offset: failedOffset, Limit: failedLimit, Err: err}
+		}
+
+		if truncated {
+			return ErrMaxPages
+		}
+	}
+
+	return nil
+}
+
+// cursorHandler is the cursor pagination counterpart of responseHandler. It
+// returns the cursor of the next page, or an empty cursor once the last page
+// has been handled. The cursorHandler is responsible for closing the response.
+type cursorHandler func(response *http.Response) (nextCursor string, err error)
+
+// pagedGetCursor is the counterpart of pagedGet for the endpoints paginated
+// with a cursor query parameter rather than with an offset. Listing starts at
+// cursor, or at the first page if it's empty, so basePath must not carry a
+// cursor of its own.
+func (c *Client) pagedGetCursor(ctx context.Context, basePath, cursor string, handler cursorHandler) error {
+	basePrefix := getBasePrefix(basePath)
+
+	for pages := 0; ; pages++ {
+		if c.pageLimitReached(pages) {
+			return ErrMaxPages
+		}
+
+		p := basePath
+		if cursor != "" {
+			p = basePrefix + "cursor=" + url.QueryEscape(cursor)
+		}
+
+		response, err := c.do(ctx, http.MethodGet, p, nil, nil)
+		if err != nil {
+			return err
+		}
+
+		next, err := handler(response)
+		if err != nil {
+			return err
+		}
+
+		if next == "" {
+			return nil
+		}
+		cursor = next
+	}
+}
@@ -1,13 +1,17 @@
 package pagerduty
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 var (
@@ -92,6 +96,374 @@ func TestGetBasePrefix(t *testing.T) {
 	}
 }
 
+func TestPagedGetCursor(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/things", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "10", r.URL.Query().Get("limit"))
+		testEqual(t, 1, len(r.URL.Query()["cursor"]))
+
+		switch cursor := r.URL.Query().Get("cursor"); cursor {
+		case "start":
+			w.Write([]byte(`next a/b`))
+		case "a/b":
+			w.Write([]byte(``))
+		default:
+			t.Errorf("unexpected cursor %q", cursor)
+		}
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	var pages int
+	handler := func(response *http.Response) (string, error) {
+		defer response.Body.Close()
+		pages++
+
+		b, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimPrefix(string(b), "next "), nil
+	}
+
+	if err := client.pagedGetCursor(context.Background(), "/things?limit=10", "start", handler); err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, 2, pages)
+}
+
+func TestWithMaxPages(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests int
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		offset := r.URL.Query().Get("offset")
+		w.Write([]byte(`{"services": [{"id": "` + offset + `"}], "offset": ` + offset + `, "limit": 1, "more": true}`))
+	})
+
+	client := NewClient("foo", WithAPIEndpoint(server.URL), WithMaxPages(2))
+
+	for _, prefetch := range []bool{false, true} {
+		requests = 0
+
+		services, err := client.ListServicesPaginated(context.Background(), ListServiceOptions{Prefetch: prefetch})
+		if !errors.Is(err, ErrMaxPages) {
+			t.Fatalf("prefetch %t: err = %v, want ErrMaxPages", prefetch, err)
+		}
+		testEqual(t, 2, len(services))
+		testEqual(t, 2, requests)
+	}
+}
+
+func TestClient_Ping(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/abilities", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.Header.Get("Authorization") != "Token token=good" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error": {"message": "Unauthorized", "code": 2006}}`))
+			return
+		}
+		w.Write([]byte(`{"abilities": ["sso"]}`))
+	})
+
+	if err := NewClient("good", WithAPIEndpoint(server.URL)).Ping(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	err := NewClient("bad", WithAPIEndpoint(server.URL)).Ping(context.Background())
+	var aerr APIError
+	if !errors.As(err, &aerr) || !aerr.Unauthorized() {
+		t.Fatalf("err = %v, want an unauthorized APIError", err)
+	}
+	testEqual(t, false, aerr.NotFound())
+}
+
+func TestWrapBody(t *testing.T) {
+	data, err := json.Marshal(wrapBody("service", Service{Name: "foo"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const want = `{"service":{"name":"foo","escalation_policy":{}}}`
+
+	if got := string(data); got != want {
+		t.Errorf("json.Marshal(wrapBody()) = %s, want %s", got, want)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestNewOAuthClient(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/1", func(w http.ResponseWriter, r *http.Request) {
+		testEqual(t, "Bearer foo", r.Header.Get("Authorization"))
+		w.Write([]byte(`{"service": {"id": "1"}}`))
+	})
+
+	// the options must be applied, or the request would go to the default API
+	// endpoint
+	client := NewOAuthClient("foo", WithAPIEndpoint(server.URL))
+
+	if _, err := client.GetService("1", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if c := NewClient("foo"); c.authType != apiToken {
+		t.Error("NewClient() doesn't use an API token")
+	}
+}
+
+func TestWithAPIEndpoint(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"service": {"id": "1"}}`))
+	})
+	mux.HandleFunc("/v2/change/enqueue", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"status": "success"}`))
+	})
+
+	client := NewClient("foo", WithAPIEndpoint(server.URL+"/"), WithV2EventsAPIEndpoint(server.URL+"//"))
+	testEqual(t, server.URL, client.apiEndpoint)
+	testEqual(t, server.URL, client.v2EventsAPIEndpoint)
+
+	if _, err := client.GetService("1", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.CreateChangeEvent(ChangeEvent{RoutingKey: "key"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if c := NewClient("foo", WithAPIEndpoint(EUAPIEndpoint)); c.apiEndpoint != "https://api.eu.pagerduty.com" {
+		t.Errorf("apiEndpoint = %q", c.apiEndpoint)
+	}
+}
+
+func TestWithDefaultFrom(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var from string
+	mux.HandleFunc("/incidents/PINC1/notes", func(w http.ResponseWriter, r *http.Request) {
+		from = r.Header.Get("From")
+		w.Write([]byte(`{"note": {"id": "PNOTE1"}}`))
+	})
+	mux.HandleFunc("/response_plays/PRP1/run", func(w http.ResponseWriter, r *http.Request) {
+		from = r.Header.Get("From")
+		w.Write([]byte(`{"status": "ok"}`))
+	})
+
+	client := NewClient("foo", WithAPIEndpoint(server.URL), WithDefaultFrom("bot@example.com"))
+
+	if _, err := client.CreateIncidentNoteWithContext(context.Background(), "", "PINC1", IncidentNote{Content: "hi"}); err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, "bot@example.com", from)
+
+	if err := client.RunResponsePlay(context.Background(), "", "PRP1", "PINC1"); err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, "bot@example.com", from)
+
+	if err := client.RunResponsePlay(context.Background(), "me@example.com", "PRP1", "PINC1"); err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, "me@example.com", from)
+}
+
+func TestClient_decodeJSONError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/html", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html>Bad Gateway</html>"))
+	})
+	mux.HandleFunc("/services/huge", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"service": {"id": "` + strings.Repeat("x", 4096)))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	_, err := client.GetService("html", nil)
+	var derr DecodeError
+	if !errors.As(err, &derr) {
+		t.Fatalf("err = %v, want a DecodeError", err)
+	}
+	testEqual(t, http.StatusOK, derr.StatusCode)
+	testEqual(t, "<html>Bad Gateway</html>", derr.Body)
+	testEqual(t, false, derr.Truncated)
+	testErrCheck(t, "GetService()", `body: "<html>Bad Gateway</html>"`, err)
+
+	_, err = client.GetService("huge", nil)
+	if !errors.As(err, &derr) {
+		t.Fatalf("err = %v, want a DecodeError", err)
+	}
+	testEqual(t, decodeErrorBodyLen, len(derr.Body))
+	testEqual(t, true, derr.Truncated)
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"service": {"id": "1"}}`))
+	})
+
+	var calls int
+	hc := &http.Client{
+		Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			calls++
+			return http.DefaultTransport.RoundTrip(r)
+		}),
+	}
+
+	client := NewClient("foo", WithAPIEndpoint(server.URL), WithHTTPClient(hc))
+
+	if _, err := client.GetService("1", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 1 {
+		t.Errorf("custom transport was called %d times, want 1", calls)
+	}
+
+	if c := NewClient("foo", WithHTTPClient(nil)); c.HTTPClient != defaultHTTPClient {
+		t.Error("WithHTTPClient(nil) replaced the default HTTP client")
+	}
+}
+
+func TestWithHTTPClient_contextWins(t *testing.T) {
+	setup()
+	defer teardown()
+
+	done := make(chan struct{})
+	defer close(done)
+
+	mux.HandleFunc("/services/1", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	})
+
+	client := NewClient("foo", WithAPIEndpoint(server.URL), WithHTTPClient(&http.Client{Timeout: time.Minute}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetServiceWithContext(ctx, "1", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("GetServiceWithContext() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+type rateLimiterFunc func(ctx context.Context) error
+
+func (f rateLimiterFunc) Wait(ctx context.Context) error {
+	return f(ctx)
+}
+
+func TestWithRateLimiter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests int
+	mux.HandleFunc("/services/1", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"service": {"id": "1"}}`))
+	})
+
+	var waits int
+	limiter := rateLimiterFunc(func(ctx context.Context) error {
+		waits++
+		if waits > 1 {
+			return errors.New("limit reached")
+		}
+		return nil
+	})
+
+	client := NewClient("foo", WithAPIEndpoint(server.URL), WithRateLimiter(limiter))
+
+	if _, err := client.GetService("1", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := client.GetService("1", nil)
+	testErrCheck(t, "GetService()", "limit reached", err)
+
+	testEqual(t, 2, waits)
+	testEqual(t, 1, requests)
+}
+
+func TestWithRequestResponseHook(t *testing.T) {
+	defer func(d time.Duration) { retryBaseDelay = d }(retryBaseDelay)
+	retryBaseDelay = time.Millisecond
+
+	setup()
+	defer teardown()
+
+	var calls int
+	mux.HandleFunc("/services/1", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"service": {"id": "1"}}`))
+	})
+
+	var statuses []int
+	var bodies []string
+	hook := func(req *http.Request, resp *http.Response, err error) {
+		if err != nil {
+			t.Errorf("hook err = %v", err)
+			return
+		}
+
+		if req.Method != http.MethodGet || req.URL.Path != "/services/1" {
+			t.Errorf("hook request = %s %s", req.Method, req.URL.Path)
+		}
+
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		statuses = append(statuses, resp.StatusCode)
+		bodies = append(bodies, string(body))
+	}
+
+	client := NewClient("foo", WithAPIEndpoint(server.URL), WithRetryPolicy(1, false), WithRequestResponseHook(hook))
+
+	s, err := client.GetService("1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "1", s.ID)
+	testEqual(t, []int{http.StatusServiceUnavailable, http.StatusOK}, statuses)
+	testEqual(t, []string{"", `{"service": {"id": "1"}}`}, bodies)
+}
+
 func TestAPIError_Error(t *testing.T) {
 	const jsonBody = `{"error":{"code": 420, "message": "Enhance Your Calm", "errors":["Enhance Your Calm", "Slow Your Roll"]}}`
 
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -42,6 +44,14 @@ type ListEscalationRulesResponse struct {
 	EscalationRules []EscalationRule `json:"escalation_rules"`
 }
 
+// The values accepted by the Includes field of ListEscalationPoliciesOptions
+// and GetEscalationPolicyOptions.
+const (
+	EscalationPolicyIncludeServices = "services"
+	EscalationPolicyIncludeTeams    = "teams"
+	EscalationPolicyIncludeTargets  = "targets"
+)
+
 // ListEscalationPoliciesOptions is the data structure used when calling the ListEscalationPolicies API endpoint.
 type ListEscalationPoliciesOptions struct {
 	APIListObject
@@ -50,6 +60,11 @@ type ListEscalationPoliciesOptions struct {
 	TeamIDs  []string `url:"team_ids,omitempty,brackets"`
 	Includes []string `url:"include,omitempty,brackets"`
 	SortBy   string   `url:"sort_by,omitempty"`
+
+	// ListAll makes ListEscalationPolicies return every escalation policy in a
+	// single response, with Limit as the page size. Offset is ignored, and ListAll
+	// isn't sent to the API.
+	ListAll bool `url:"-"`
 }
 
 // GetEscalationRuleOptions is the data structure used when calling the GetEscalationRule API endpoint.
@@ -59,11 +74,32 @@ type GetEscalationRuleOptions struct {
 
 // ListEscalationPolicies lists all of the existing escalation policies.
 func (c *Client) ListEscalationPolicies(o ListEscalationPoliciesOptions) (*ListEscalationPoliciesResponse, error) {
+	return c.ListEscalationPoliciesWithContext(context.TODO(), o)
+}
+
+// ListEscalationPoliciesWithContext lists all of the existing escalation policies.
+func (c *Client) ListEscalationPoliciesWithContext(ctx context.Context, o ListEscalationPoliciesOptions) (*ListEscalationPoliciesResponse, error) {
+	if o.ListAll {
+		var all ListEscalationPoliciesResponse
+		responseHandler := func(response *http.Response) (APIListObject, error) {
+			var result ListEscalationPoliciesResponse
+			if err := c.decodeJSON(response, &result); err != nil {
+				return APIListObject{}, err
+			}
+			all.EscalationPolicies = append(all.EscalationPolicies, result.EscalationPolicies...)
+			return result.APIListObject, nil
+		}
+		if err := c.pagedGetAll(ctx, escPath, o, responseHandler); err != nil {
+			return nil, err
+		}
+		return &all, nil
+	}
+
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.get(context.TODO(), escPath+"?"+v.Encode())
+	resp, err := c.get(ctx, escPath+"?"+v.Encode())
 	if err != nil {
 		return nil, err
 	}
@@ -73,15 +109,23 @@ func (c *Client) ListEscalationPolicies(o ListEscalationPoliciesOptions) (*ListE
 
 // CreateEscalationPolicy creates a new escalation policy.
 func (c *Client) CreateEscalationPolicy(e EscalationPolicy) (*EscalationPolicy, error) {
-	data := make(map[string]EscalationPolicy)
-	data["escalation_policy"] = e
-	resp, err := c.post(context.TODO(), escPath, data, nil)
+	return c.CreateEscalationPolicyWithContext(context.TODO(), e)
+}
+
+// CreateEscalationPolicyWithContext creates a new escalation policy.
+func (c *Client) CreateEscalationPolicyWithContext(ctx context.Context, e EscalationPolicy) (*EscalationPolicy, error) {
+	resp, err := c.post(ctx, escPath, wrapBody("escalation_policy", e), nil)
 	return getEscalationPolicyFromResponse(c, resp, err)
 }
 
 // DeleteEscalationPolicy deletes an existing escalation policy and rules.
 func (c *Client) DeleteEscalationPolicy(id string) error {
-	_, err := c.delete(context.TODO(), escPath+"/"+id)
+	return c.DeleteEscalationPolicyWithContext(context.TODO(), id)
+}
+
+// DeleteEscalationPolicyWithContext deletes an existing escalation policy and rules.
+func (c *Client) DeleteEscalationPolicyWithContext(ctx context.Context, id string) error {
+	_, err := c.delete(ctx, escPath+"/"+id)
 	return err
 }
 
@@ -92,28 +136,166 @@ type GetEscalationPolicyOptions struct {
 
 // GetEscalationPolicy gets information about an existing escalation policy and its rules.
 func (c *Client) GetEscalationPolicy(id string, o *GetEscalationPolicyOptions) (*EscalationPolicy, error) {
+	return c.GetEscalationPolicyWithContext(context.TODO(), id, o)
+}
+
+// GetEscalationPolicyWithContext gets information about an existing escalation policy and its rules.
+func (c *Client) GetEscalationPolicyWithContext(ctx context.Context, id string, o *GetEscalationPolicyOptions) (*EscalationPolicy, error) {
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.get(context.TODO(), escPath+"/"+id+"?"+v.Encode())
+	resp, err := c.get(ctx, escPath+"/"+id+"?"+v.Encode())
 	return getEscalationPolicyFromResponse(c, resp, err)
 }
 
+// EscalationCoverage is the effective on-call timeline of an escalation
+// policy over a window of time.
+type EscalationCoverage struct {
+	EscalationPolicyID string
+	Since              time.Time
+	Until              time.Time
+
+	// Levels holds one entry per escalation rule, in escalation order. A
+	// level with no Entries has nobody on call for the whole window.
+	Levels []EscalationLevelCoverage
+}
+
+// EscalationLevelCoverage is the on-call timeline for a single level of an
+// escalation policy.
+type EscalationLevelCoverage struct {
+	Level   uint
+	Entries []EscalationCoverageEntry
+}
+
+// EscalationCoverageEntry is a span of time during which User is on call at a
+// given level. Schedule is the schedule that put them there, and is empty when
+// the user is targeted directly by the escalation rule.
+type EscalationCoverageEntry struct {
+	User     User
+	Schedule Schedule
+	Start    time.Time
+	End      time.Time
+}
+
+// GetEscalationCoverage returns who is on call at each level of an escalation
+// policy between since and until, and when that changes. It is built from the
+// rendered on-call entries, so schedule overrides and handoffs within the
+// window are reflected. Entries are clamped to the window, including entries
+// for users targeted directly by a rule, which have no start or end.
+func (c *Client) GetEscalationCoverage(ctx context.Context, policyID string, since, until time.Time) (*EscalationCoverage, error) {
+	if !until.After(since) {
+		return nil, fmt.Errorf("until %s must be after since %s", until.Format(time.RFC3339), since.Format(time.RFC3339))
+	}
+
+	ep, err := c.GetEscalationPolicyWithContext(ctx, policyID, &GetEscalationPolicyOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	coverage := &EscalationCoverage{
+		EscalationPolicyID: policyID,
+		Since:              since,
+		Until:              until,
+		Levels:             make([]EscalationLevelCoverage, len(ep.EscalationRules)),
+	}
+
+	for i := range coverage.Levels {
+		coverage.Levels[i].Level = uint(i + 1)
+	}
+
+	v, err := query.Values(ListOnCallOptions{
+		EscalationPolicyIDs: []string{policyID},
+		Since:               since.Format(time.RFC3339),
+		Until:               until.Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		var result ListOnCallsResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return APIListObject{}, err
+		}
+
+		for _, oc := range result.OnCalls {
+			if oc.EscalationLevel == 0 {
+				continue
+			}
+
+			entry := EscalationCoverageEntry{
+				User:     oc.User,
+				Schedule: oc.Schedule,
+				Start:    since,
+				End:      until,
+			}
+
+			if oc.Start != "" {
+				t, err := time.Parse(time.RFC3339, oc.Start)
+				if err != nil {
+					return APIListObject{}, fmt.Errorf("failed to parse on-call start time: %w", err)
+				}
+				if t.After(since) {
+					entry.Start = t
+				}
+			}
+
+			if oc.End != "" {
+				t, err := time.Parse(time.RFC3339, oc.End)
+				if err != nil {
+					return APIListObject{}, fmt.Errorf("failed to parse on-call end time: %w", err)
+				}
+				if t.Before(until) {
+					entry.End = t
+				}
+			}
+
+			// grow the levels if the rules changed since we fetched the policy
+			for uint(len(coverage.Levels)) < oc.EscalationLevel {
+				coverage.Levels = append(coverage.Levels, EscalationLevelCoverage{Level: uint(len(coverage.Levels) + 1)})
+			}
+
+			level := &coverage.Levels[oc.EscalationLevel-1]
+			level.Entries = append(level.Entries, entry)
+		}
+
+		return APIListObject{
+			More:   result.More,
+			Offset: result.Offset,
+			Limit:  result.Limit,
+		}, nil
+	}
+
+	if err := c.pagedGet(ctx, "/oncalls?"+v.Encode(), responseHandler); err != nil {
+		return nil, err
+	}
+
+	for _, level := range coverage.Levels {
+		entries := level.Entries
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].Start.Before(entries[j].Start)
+		})
+	}
+
+	return coverage, nil
+}
+
 // UpdateEscalationPolicy updates an existing escalation policy and its rules.
 func (c *Client) UpdateEscalationPolicy(id string, e *EscalationPolicy) (*EscalationPolicy, error) {
-	data := make(map[string]EscalationPolicy)
-	data["escalation_policy"] = *e
-	resp, err := c.put(context.TODO(), escPath+"/"+id, data, nil)
+	return c.UpdateEscalationPolicyWithContext(context.TODO(), id, e)
+}
+
+// UpdateEscalationPolicyWithContext updates an existing escalation policy and its rules.
+func (c *Client) UpdateEscalationPolicyWithContext(ctx context.Context, id string, e *EscalationPolicy) (*EscalationPolicy, error) {
+	resp, err := c.put(ctx, escPath+"/"+id, wrapBody("escalation_policy", *e), nil)
 	return getEscalationPolicyFromResponse(c, resp, err)
 }
 
 // CreateEscalationRule creates a new escalation rule for an escalation policy
 // and appends it to the end of the existing escalation rules.
 func (c *Client) CreateEscalationRule(escID string, e EscalationRule) (*EscalationRule, error) {
-	data := make(map[string]EscalationRule)
-	data["escalation_rule"] = e
-	resp, err := c.post(context.TODO(), escPath+"/"+escID+"/escalation_rules", data, nil)
+	resp, err := c.post(context.TODO(), escPath+"/"+escID+"/escalation_rules", wrapBody("escalation_rule", e), nil)
 	return getEscalationRuleFromResponse(c, resp, err)
 }
 
@@ -135,9 +317,7 @@ func (c *Client) DeleteEscalationRule(escID string, id string) error {
 
 // UpdateEscalationRule updates an existing escalation rule.
 func (c *Client) UpdateEscalationRule(escID string, id string, e *EscalationRule) (*EscalationRule, error) {
-	data := make(map[string]EscalationRule)
-	data["escalation_rule"] = *e
-	resp, err := c.put(context.TODO(), escPath+"/"+escID+"/escalation_rules/"+id, data, nil)
+	resp, err := c.put(context.TODO(), escPath+"/"+escID+"/escalation_rules/"+id, wrapBody("escalation_rule", *e), nil)
 	return getEscalationRuleFromResponse(c, resp, err)
 }
 
@@ -1,8 +1,10 @@
 package pagerduty
 
 import (
+	"context"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func TestEscalationPolicy_List(t *testing.T) {
@@ -36,6 +38,43 @@ func TestEscalationPolicy_List(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+func TestEscalationPolicy_ListWithContextIncludes(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/escalation_policies", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, []string{"teams", "targets"}, r.URL.Query()["include[]"])
+		w.Write([]byte(`{"escalation_policies": [{"id": "1", "escalation_rules": [{"id": "R1", "escalation_delay_in_minutes": 30, "targets": [{"id": "S1", "type": "schedule_reference"}]}]}]}`))
+	})
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	opts := ListEscalationPoliciesOptions{
+		Includes: []string{EscalationPolicyIncludeTeams, EscalationPolicyIncludeTargets},
+	}
+	res, err := client.ListEscalationPoliciesWithContext(context.Background(), opts)
+
+	want := &ListEscalationPoliciesResponse{
+		EscalationPolicies: []EscalationPolicy{
+			{
+				APIObject: APIObject{ID: "1"},
+				EscalationRules: []EscalationRule{
+					{
+						ID:      "R1",
+						Delay:   30,
+						Targets: []APIObject{{ID: "S1", Type: "schedule_reference"}},
+					},
+				},
+			},
+		},
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, want, res)
+}
+
 func TestEscalationPolicy_Create(t *testing.T) {
 	setup()
 	defer teardown()
@@ -159,3 +198,60 @@ func TestEscalationPolicy_UpdateTeams(t *testing.T) {
 	}
 	testEqual(t, want, res)
 }
+
+func TestEscalationPolicy_GetCoverage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/escalation_policies/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Write([]byte(`{"escalation_policy": {"id": "1", "escalation_rules": [{"id": "R1"}, {"id": "R2"}, {"id": "R3"}]}}`))
+	})
+	mux.HandleFunc("/oncalls", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got := r.URL.Query().Get("escalation_policy_ids[]"); got != "1" {
+			t.Errorf("escalation_policy_ids[] = %q, want %q", got, "1")
+		}
+		w.Write([]byte(`{"oncalls": [
+			{"user": {"id": "U2"}, "schedule": {"id": "S1"}, "escalation_level": 1, "start": "2020-10-01T12:00:00Z", "end": "2020-10-02T12:00:00Z"},
+			{"user": {"id": "U1"}, "schedule": {"id": "S1"}, "escalation_level": 1, "start": "2020-09-30T12:00:00Z", "end": "2020-10-01T12:00:00Z"},
+			{"user": {"id": "U3"}, "escalation_level": 2, "start": null, "end": null}
+		], "more": false}`))
+	})
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	since := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2020, 10, 2, 0, 0, 0, 0, time.UTC)
+	noon := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	res, err := client.GetEscalationCoverage(context.Background(), "1", since, until)
+
+	want := &EscalationCoverage{
+		EscalationPolicyID: "1",
+		Since:              since,
+		Until:              until,
+		Levels: []EscalationLevelCoverage{
+			{
+				Level: 1,
+				Entries: []EscalationCoverageEntry{
+					{User: User{APIObject: APIObject{ID: "U1"}}, Schedule: Schedule{APIObject: APIObject{ID: "S1"}}, Start: since, End: noon},
+					{User: User{APIObject: APIObject{ID: "U2"}}, Schedule: Schedule{APIObject: APIObject{ID: "S1"}}, Start: noon, End: until},
+				},
+			},
+			{
+				Level: 2,
+				Entries: []EscalationCoverageEntry{
+					{User: User{APIObject: APIObject{ID: "U3"}}, Start: since, End: until},
+				},
+			},
+			{
+				Level: 3,
+			},
+		},
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, want, res)
+}
@@ -0,0 +1,492 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-querystring/query"
+)
+
+// EventOrchestration is a global event orchestration, which receives events
+// on its integrations' routing keys and routes them to services.
+type EventOrchestration struct {
+	ID           string                           `json:"id,omitempty"`
+	Self         string                           `json:"self,omitempty"`
+	Name         string                           `json:"name"`
+	Description  string                           `json:"description,omitempty"`
+	Team         *APIObject                       `json:"team,omitempty"`
+	Integrations []*EventOrchestrationIntegration `json:"integrations,omitempty"`
+
+	// Routes is the number of rules of the orchestration's router.
+	Routes uint `json:"routes,omitempty"`
+
+	CreatedAt string     `json:"created_at,omitempty"`
+	CreatedBy *APIObject `json:"created_by,omitempty"`
+	UpdatedAt string     `json:"updated_at,omitempty"`
+	UpdatedBy *APIObject `json:"updated_by,omitempty"`
+	Version   string     `json:"version,omitempty"`
+}
+
+// EventOrchestrationIntegration is a routing key events can be sent to for an
+// event orchestration to process them.
+type EventOrchestrationIntegration struct {
+	ID         string                                   `json:"id,omitempty"`
+	Parameters *EventOrchestrationIntegrationParameters `json:"parameters,omitempty"`
+}
+
+// EventOrchestrationIntegrationParameters are the parameters of an event
+// orchestration integration.
+type EventOrchestrationIntegrationParameters struct {
+	RoutingKey string `json:"routing_key,omitempty"`
+	Type       string `json:"type,omitempty"`
+}
+
+// ListEventOrchestrationsOptions is the data structure used when calling the
+// ListOrchestrations API endpoint.
+type ListEventOrchestrationsOptions struct {
+	APIListObject
+	SortBy string `url:"sort_by,omitempty"`
+
+	// ListAll makes ListOrchestrations return every orchestration in a single
+	// response, with Limit as the page size. Offset is ignored, and ListAll isn't
+	// sent to the API.
+	ListAll bool `url:"-"`
+}
+
+// ListEventOrchestrationsResponse is the data structure returned from calling
+// the ListOrchestrations API endpoint.
+type ListEventOrchestrationsResponse struct {
+	APIListObject
+	Orchestrations []EventOrchestration `json:"orchestrations"`
+}
+
+// The values of the Type field of OrchestrationPath.
+const (
+	OrchestrationPathTypeRouter  = "router"
+	OrchestrationPathTypeGlobal  = "global"
+	OrchestrationPathTypeService = "service"
+)
+
+// OrchestrationPath is the set of rules an event goes through at one stage of
+// an event orchestration: its router, its global rules, or the rules of a
+// service. Events start at the rule set whose ID is "start" and stop at the
+// first rule that matches; if none does the CatchAll actions apply.
+type OrchestrationPath struct {
+	Type     string                     `json:"type,omitempty"`
+	Parent   *APIObject                 `json:"parent,omitempty"`
+	Sets     []*OrchestrationPathSet    `json:"sets"`
+	CatchAll *OrchestrationPathCatchAll `json:"catch_all,omitempty"`
+
+	CreatedAt string     `json:"created_at,omitempty"`
+	CreatedBy *APIObject `json:"created_by,omitempty"`
+	UpdatedAt string     `json:"updated_at,omitempty"`
+	UpdatedBy *APIObject `json:"updated_by,omitempty"`
+	Version   string     `json:"version,omitempty"`
+}
+
+// OrchestrationPathSet is an ordered set of rules.
+type OrchestrationPathSet struct {
+	ID    string                   `json:"id"`
+	Rules []*OrchestrationPathRule `json:"rules"`
+}
+
+// OrchestrationPathRule applies its actions to the events matching any of its
+// conditions.
+type OrchestrationPathRule struct {
+	ID         string                            `json:"id,omitempty"`
+	Label      string                            `json:"label,omitempty"`
+	Conditions []*OrchestrationPathRuleCondition `json:"conditions"`
+	Actions    *OrchestrationPathRuleActions     `json:"actions,omitempty"`
+	Disabled   bool                              `json:"disabled,omitempty"`
+}
+
+// OrchestrationPathRuleCondition is a PagerDuty Condition Language expression,
+// such as `event.summary matches part 'disk'`.
+type OrchestrationPathRuleCondition struct {
+	Expression string `json:"expression"`
+}
+
+// OrchestrationPathRuleActions are the changes a rule makes to the events it
+// matches. Not every action applies to every type of path: a router's rules
+// only support RouteTo, which names the service to send the event to. In the
+// other paths RouteTo names another rule set of the same path.
+type OrchestrationPathRuleActions struct {
+	RouteTo string `json:"route_to,omitempty"`
+
+	Suppress  bool   `json:"suppress,omitempty"`
+	Suspend   *uint  `json:"suspend,omitempty"`
+	DropEvent bool   `json:"drop_event,omitempty"`
+	Priority  string `json:"priority,omitempty"`
+	Annotate  string `json:"annotate,omitempty"`
+
+	// Severity and EventAction override those of the event, such as
+	// "critical" and "trigger".
+	Severity    string `json:"severity,omitempty"`
+	EventAction string `json:"event_action,omitempty"`
+
+	EscalationPolicy *string `json:"escalation_policy,omitempty"`
+
+	PagerdutyAutomationAction *OrchestrationPathPagerdutyAutomationAction `json:"pagerduty_automation_action,omitempty"`
+	AutomationAction          *OrchestrationPathAutomationAction          `json:"automation_action,omitempty"`
+
+	Variables   []*OrchestrationPathActionVariable   `json:"variables,omitempty"`
+	Extractions []*OrchestrationPathActionExtraction `json:"extractions,omitempty"`
+}
+
+// OrchestrationPathPagerdutyAutomationAction runs a PagerDuty Automation
+// Actions action when a rule matches.
+type OrchestrationPathPagerdutyAutomationAction struct {
+	ActionID string `json:"action_id"`
+}
+
+// OrchestrationPathAutomationAction sends a webhook when a rule matches.
+type OrchestrationPathAutomationAction struct {
+	Name       string                                    `json:"name"`
+	URL        string                                    `json:"url"`
+	AutoSend   bool                                      `json:"auto_send,omitempty"`
+	Headers    []*OrchestrationPathAutomationActionField `json:"headers,omitempty"`
+	Parameters []*OrchestrationPathAutomationActionField `json:"parameters,omitempty"`
+}
+
+// OrchestrationPathAutomationActionField is a header or parameter of an
+// OrchestrationPathAutomationAction.
+type OrchestrationPathAutomationActionField struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// OrchestrationPathActionVariable captures a value of the event, by regex from
+// the field at Path, for use in later actions as {{variables.<Name>}}.
+type OrchestrationPathActionVariable struct {
+	Name  string `json:"name"`
+	Path  string `json:"path"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// OrchestrationPathActionExtraction sets the event field Target, either from
+// Source matched against Regex or from Template.
+type OrchestrationPathActionExtraction struct {
+	Target   string `json:"target"`
+	Regex    string `json:"regex,omitempty"`
+	Source   string `json:"source,omitempty"`
+	Template string `json:"template,omitempty"`
+}
+
+// OrchestrationPathCatchAll holds the actions applied to the events no rule
+// matched.
+type OrchestrationPathCatchAll struct {
+	Actions *OrchestrationPathRuleActions `json:"actions"`
+}
+
+// ListOrchestrations lists the account's global event orchestrations.
+func (c *Client) ListOrchestrations(ctx context.Context, o ListEventOrchestrationsOptions) (*ListEventOrchestrationsResponse, error) {
+	if o.ListAll {
+		var all ListEventOrchestrationsResponse
+		responseHandler := func(response *http.Response) (APIListObject, error) {
+			var result ListEventOrchestrationsResponse
+			if err := c.decodeJSON(response, &result); err != nil {
+				return APIListObject{}, err
+			}
+			all.Orchestrations = append(all.Orchestrations, result.Orchestrations...)
+			return result.APIListObject, nil
+		}
+		if err := c.pagedGetAll(ctx, "/event_orchestrations", o, responseHandler); err != nil {
+			return nil, err
+		}
+		return &all, nil
+	}
+
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.get(ctx, "/event_orchestrations?"+v.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListEventOrchestrationsResponse
+	return &result, c.decodeJSON(resp, &result)
+}
+
+// GetOrchestration gets details about an existing global event orchestration.
+func (c *Client) GetOrchestration(ctx context.Context, id string) (*EventOrchestration, error) {
+	resp, err := c.get(ctx, "/event_orchestrations/"+id)
+	return getEventOrchestrationFromResponse(c, resp, err)
+}
+
+// CreateOrchestration creates a new global event orchestration.
+func (c *Client) CreateOrchestration(ctx context.Context, o EventOrchestration) (*EventOrchestration, error) {
+	resp, err := c.post(ctx, "/event_orchestrations", wrapBody("orchestration", o), nil)
+	return getEventOrchestrationFromResponse(c, resp, err)
+}
+
+// UpdateOrchestration updates an existing global event orchestration.
+func (c *Client) UpdateOrchestration(ctx context.Context, id string, o EventOrchestration) (*EventOrchestration, error) {
+	resp, err := c.put(ctx, "/event_orchestrations/"+id, wrapBody("orchestration", o), nil)
+	return getEventOrchestrationFromResponse(c, resp, err)
+}
+
+// DeleteOrchestration deletes a global event orchestration.
+func (c *Client) DeleteOrchestration(ctx context.Context, id string) error {
+	_, err := c.delete(ctx, "/event_orchestrations/"+id)
+	return err
+}
+
+// GetOrchestrationRouter gets the router of a global event orchestration,
+// which decides which service each event is sent to.
+func (c *Client) GetOrchestrationRouter(ctx context.Context, id string) (*OrchestrationPath, error) {
+	resp, err := c.get(ctx, "/event_orchestrations/"+id+"/router")
+	return getOrchestrationPathFromResponse(c, resp, err)
+}
+
+// UpdateOrchestrationRouter replaces the router of a global event
+// orchestration.
+func (c *Client) UpdateOrchestrationRouter(ctx context.Context, id string, p OrchestrationPath) (*OrchestrationPath, error) {
+	resp, err := c.put(ctx, "/event_orchestrations/"+id+"/router", wrapBody("orchestration_path", p), nil)
+	return getOrchestrationPathFromResponse(c, resp, err)
+}
+
+// GetOrchestrationGlobalRules gets the global rules of a global event
+// orchestration, which apply to events before they're routed.
+func (c *Client) GetOrchestrationGlobalRules(ctx context.Context, id string) (*OrchestrationPath, error) {
+	resp, err := c.get(ctx, "/event_orchestrations/"+id+"/global")
+	return getOrchestrationPathFromResponse(c, resp, err)
+}
+
+// UpdateOrchestrationGlobalRules replaces the global rules of a global event
+// orchestration.
+func (c *Client) UpdateOrchestrationGlobalRules(ctx context.Context, id string, p OrchestrationPath) (*OrchestrationPath, error) {
+	resp, err := c.put(ctx, "/event_orchestrations/"+id+"/global", wrapBody("orchestration_path", p), nil)
+	return getOrchestrationPathFromResponse(c, resp, err)
+}
+
+// GetOrchestrationServiceRules gets the event orchestration rules of a
+// service, which apply to the events routed to it.
+func (c *Client) GetOrchestrationServiceRules(ctx context.Context, serviceID string) (*OrchestrationPath, error) {
+	resp, err := c.get(ctx, "/event_orchestrations/services/"+serviceID)
+	return getOrchestrationPathFromResponse(c, resp, err)
+}
+
+// UpdateOrchestrationServiceRules replaces the event orchestration rules of a
+// service.
+func (c *Client) UpdateOrchestrationServiceRules(ctx context.Context, serviceID string, p OrchestrationPath) (*OrchestrationPath, error) {
+	resp, err := c.put(ctx, "/event_orchestrations/services/"+serviceID, wrapBody("orchestration_path", p), nil)
+	return getOrchestrationPathFromResponse(c, resp, err)
+}
+
+// ConvertServiceRulesToOrchestration reads the legacy rules of a service and
+// returns the equivalent event orchestration service rules, as converted by
+// ServiceRulesToOrchestrationPath. Nothing is changed on the service; pass the
+// result to UpdateOrchestrationServiceRules to apply it.
+func (c *Client) ConvertServiceRulesToOrchestration(ctx context.Context, serviceID string) (*OrchestrationPath, error) {
+	rules, err := c.ListServiceRulesWithContext(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ServiceRulesToOrchestrationPath(rules.Rules)
+}
+
+// ServiceRulesToOrchestrationPath converts legacy service rules, in order, into
+// the "start" rule set of an event orchestration service path:
+//
+//   - An "and" condition becomes a single expression joining its subconditions
+//     with "and"; an "or" condition becomes one orchestration condition per
+//     subcondition, as a rule matches when any of its conditions do.
+//   - The subcondition operators equals, contains and matches become the
+//     Condition Language's matches, matches part and matches regex, exists
+//     stays exists, and their negated forms (nequals, ...) are prefixed with
+//     not. Paths such as summary or payload.summary become event.summary.
+//   - The annotate, event_action, priority and severity actions carry over
+//     their value, suppress becomes suppress and extractions keep their
+//     target, source and regex, prefixed with event. like paths.
+//
+// Rules using a time frame, a suppress threshold or suspend have no direct
+// equivalent and are reported as an error rather than silently changed.
+func ServiceRulesToOrchestrationPath(rules []*ServiceRule) (*OrchestrationPath, error) {
+	converted := make([]*OrchestrationPathRule, 0, len(rules))
+
+	for i, r := range rules {
+		or, err := serviceRuleToOrchestrationRule(r)
+		if err != nil {
+			return nil, fmt.Errorf("service rule %d (%s): %w", i, r.ID, err)
+		}
+		converted = append(converted, or)
+	}
+
+	return &OrchestrationPath{
+		Type: OrchestrationPathTypeService,
+		Sets: []*OrchestrationPathSet{
+			{ID: "start", Rules: converted},
+		},
+		CatchAll: &OrchestrationPathCatchAll{Actions: &OrchestrationPathRuleActions{}},
+	}, nil
+}
+
+func serviceRuleToOrchestrationRule(r *ServiceRule) (*OrchestrationPathRule, error) {
+	if r.TimeFrame != nil {
+		return nil, fmt.Errorf("time frames can't be converted")
+	}
+
+	rule := &OrchestrationPathRule{
+		Disabled:   r.Disabled,
+		Conditions: []*OrchestrationPathRuleCondition{},
+		Actions:    &OrchestrationPathRuleActions{},
+	}
+
+	if r.Conditions != nil && len(r.Conditions.RuleSubconditions) > 0 {
+		expressions := make([]string, 0, len(r.Conditions.RuleSubconditions))
+		for _, sc := range r.Conditions.RuleSubconditions {
+			e, err := subconditionToExpression(sc)
+			if err != nil {
+				return nil, err
+			}
+			expressions = append(expressions, e)
+		}
+
+		switch r.Conditions.Operator {
+		case "and", "":
+			rule.Conditions = append(rule.Conditions, &OrchestrationPathRuleCondition{Expression: strings.Join(expressions, " and ")})
+		case "or":
+			for _, e := range expressions {
+				rule.Conditions = append(rule.Conditions, &OrchestrationPathRuleCondition{Expression: e})
+			}
+		default:
+			return nil, fmt.Errorf("unsupported condition operator %q", r.Conditions.Operator)
+		}
+	}
+
+	if a := r.Actions; a != nil {
+		if a.Annotate != nil {
+			rule.Actions.Annotate = a.Annotate.Value
+		}
+		if a.EventAction != nil {
+			rule.Actions.EventAction = a.EventAction.Value
+		}
+		if a.Priority != nil {
+			rule.Actions.Priority = a.Priority.Value
+		}
+		if a.Severity != nil {
+			rule.Actions.Severity = a.Severity.Value
+		}
+
+		if a.Suppress != nil {
+			if a.Suppress.ThresholdValue != 0 || a.Suppress.ThresholdTimeAmount != 0 || a.Suppress.ThresholdTimeUnit != "" {
+				return nil, fmt.Errorf("suppress thresholds can't be converted")
+			}
+			rule.Actions.Suppress = a.Suppress.Value
+		}
+
+		if a.Suspend != nil && a.Suspend.Value {
+			return nil, fmt.Errorf("suspend can't be converted")
+		}
+
+		for _, e := range a.Extractions {
+			rule.Actions.Extractions = append(rule.Actions.Extractions, &OrchestrationPathActionExtraction{
+				Target: orchestrationEventPath(e.Target),
+				Source: orchestrationEventPath(e.Source),
+				Regex:  e.Regex,
+			})
+		}
+	}
+
+	return rule, nil
+}
+
+// subconditionToExpression converts a legacy subcondition into a Condition
+// Language expression.
+func subconditionToExpression(sc *RuleSubcondition) (string, error) {
+	if sc.Parameters == nil {
+		return "", fmt.Errorf("subcondition %q has no parameters", sc.Operator)
+	}
+
+	// None of the positive operators start with an n, so it marks the negated
+	// ones: nequals, ncontains, nmatches and nexists.
+	op := sc.Operator
+	negated := strings.HasPrefix(op, "n")
+	if negated {
+		op = op[1:]
+	}
+
+	field := orchestrationEventPath(sc.Parameters.Path)
+
+	var e string
+	switch op {
+	case "exists":
+		e = field + " exists"
+	case "equals":
+		e = field + " matches " + quoteConditionValue(sc.Parameters.Value)
+	case "contains":
+		e = field + " matches part " + quoteConditionValue(sc.Parameters.Value)
+	case "matches":
+		e = field + " matches regex " + quoteConditionValue(sc.Parameters.Value)
+	default:
+		return "", fmt.Errorf("unsupported subcondition operator %q", sc.Operator)
+	}
+
+	if negated {
+		e = "not " + e
+	}
+
+	return e, nil
+}
+
+// orchestrationEventPath turns the path of an event field in a legacy rule,
+// such as summary or payload.summary, into its Condition Language form.
+func orchestrationEventPath(p string) string {
+	if p == "" {
+		return ""
+	}
+	return "event." + strings.TrimPrefix(p, "payload.")
+}
+
+func quoteConditionValue(v string) string {
+	v = strings.ReplaceAll(v, `\`, `\\`)
+	v = strings.ReplaceAll(v, "'", `\'`)
+	return "'" + v + "'"
+}
+
+func getEventOrchestrationFromResponse(c *Client, resp *http.Response, err error) (*EventOrchestration, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	var target map[string]EventOrchestration
+	if dErr := c.decodeJSON(resp, &target); dErr != nil {
+		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
+	}
+
+	const rootNode = "orchestration"
+
+	t, nodeOK := target[rootNode]
+	if !nodeOK {
+		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
+	}
+
+	return &t, nil
+}
+
+func getOrchestrationPathFromResponse(c *Client, resp *http.Response, err error) (*OrchestrationPath, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	var target map[string]OrchestrationPath
+	if dErr := c.decodeJSON(resp, &target); dErr != nil {
+		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
+	}
+
+	const rootNode = "orchestration_path"
+
+	t, nodeOK := target[rootNode]
+	if !nodeOK {
+		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
+	}
+
+	return &t, nil
+}
@@ -0,0 +1,302 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// List Event Orchestrations
+func TestEventOrchestration_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/event_orchestrations", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Write([]byte(`{"orchestrations": [{"id": "EO1", "name": "Shared", "routes": 3, "team": {"id": "PTEAM1"}}], "limit": 25, "more": false}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.ListOrchestrations(context.Background(), ListEventOrchestrationsOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []EventOrchestration{
+		{ID: "EO1", Name: "Shared", Routes: 3, Team: &APIObject{ID: "PTEAM1"}},
+	}
+	testEqual(t, want, res.Orchestrations)
+}
+
+// Create Event Orchestration
+func TestEventOrchestration_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/event_orchestrations", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body map[string]EventOrchestration
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		testEqual(t, "Shared", body["orchestration"].Name)
+
+		w.Write([]byte(`{"orchestration": {"id": "EO1", "name": "Shared", "integrations": [{"id": "I1", "parameters": {"routing_key": "R1", "type": "global"}}]}}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.CreateOrchestration(context.Background(), EventOrchestration{Name: "Shared"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &EventOrchestration{
+		ID:   "EO1",
+		Name: "Shared",
+		Integrations: []*EventOrchestrationIntegration{
+			{ID: "I1", Parameters: &EventOrchestrationIntegrationParameters{RoutingKey: "R1", Type: "global"}},
+		},
+	}
+	testEqual(t, want, res)
+}
+
+// Delete Event Orchestration
+func TestEventOrchestration_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/event_orchestrations/EO1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	if err := client.DeleteOrchestration(context.Background(), "EO1"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Get Event Orchestration Router
+func TestEventOrchestration_GetRouter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/event_orchestrations/EO1/router", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Write([]byte(`{"orchestration_path": {
+			"type": "router",
+			"parent": {"id": "EO1", "type": "event_orchestration_reference"},
+			"sets": [{"id": "start", "rules": [{"id": "R1", "label": "db", "conditions": [{"expression": "event.summary matches part 'db'"}], "actions": {"route_to": "PSVC1"}}]}],
+			"catch_all": {"actions": {"route_to": "unrouted"}}
+		}}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.GetOrchestrationRouter(context.Background(), "EO1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &OrchestrationPath{
+		Type:   OrchestrationPathTypeRouter,
+		Parent: &APIObject{ID: "EO1", Type: "event_orchestration_reference"},
+		Sets: []*OrchestrationPathSet{
+			{
+				ID: "start",
+				Rules: []*OrchestrationPathRule{
+					{
+						ID:         "R1",
+						Label:      "db",
+						Conditions: []*OrchestrationPathRuleCondition{{Expression: "event.summary matches part 'db'"}},
+						Actions:    &OrchestrationPathRuleActions{RouteTo: "PSVC1"},
+					},
+				},
+			},
+		},
+		CatchAll: &OrchestrationPathCatchAll{Actions: &OrchestrationPathRuleActions{RouteTo: "unrouted"}},
+	}
+	testEqual(t, want, res)
+}
+
+// Update Event Orchestration Service Rules
+func TestEventOrchestration_UpdateServiceRules(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/event_orchestrations/services/PSVC1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var body map[string]OrchestrationPath
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		actions := body["orchestration_path"].Sets[0].Rules[0].Actions
+		testEqual(t, "critical", actions.Severity)
+		testEqual(t, []*OrchestrationPathActionExtraction{{Target: "event.summary", Template: "{{variables.host}} down"}}, actions.Extractions)
+
+		w.Write([]byte(`{"orchestration_path": {"type": "service", "sets": [{"id": "start", "rules": []}]}}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	p := OrchestrationPath{
+		Sets: []*OrchestrationPathSet{
+			{
+				ID: "start",
+				Rules: []*OrchestrationPathRule{
+					{
+						Conditions: []*OrchestrationPathRuleCondition{{Expression: "event.severity matches 'critical'"}},
+						Actions: &OrchestrationPathRuleActions{
+							Severity:    "critical",
+							Extractions: []*OrchestrationPathActionExtraction{{Target: "event.summary", Template: "{{variables.host}} down"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	res, err := client.UpdateOrchestrationServiceRules(context.Background(), "PSVC1", p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, OrchestrationPathTypeService, res.Type)
+}
+
+// Convert Service Rules to Event Orchestration
+func TestServiceRulesToOrchestrationPath(t *testing.T) {
+	rules := []*ServiceRule{
+		{
+			ID: "R1",
+			Conditions: &RuleConditions{
+				Operator: "and",
+				RuleSubconditions: []*RuleSubcondition{
+					{Operator: "contains", Parameters: &ConditionParameter{Path: "summary", Value: "db"}},
+					{Operator: "nequals", Parameters: &ConditionParameter{Path: "payload.severity", Value: "info"}},
+				},
+			},
+			Actions: &ServiceRuleActions{
+				Severity:    &RuleActionParameter{Value: "critical"},
+				Priority:    &RuleActionParameter{Value: "PPRI1"},
+				Extractions: []*RuleActionExtraction{{Target: "dedup_key", Source: "details.host", Regex: "(.*)"}},
+			},
+		},
+		{
+			ID:       "R2",
+			Disabled: true,
+			Conditions: &RuleConditions{
+				Operator: "or",
+				RuleSubconditions: []*RuleSubcondition{
+					{Operator: "matches", Parameters: &ConditionParameter{Path: "source", Value: "it's.*"}},
+					{Operator: "nexists", Parameters: &ConditionParameter{Path: "class"}},
+				},
+			},
+			Actions: &ServiceRuleActions{
+				Suppress: &RuleActionSuppress{Value: true},
+				Annotate: &RuleActionParameter{Value: "noise"},
+			},
+		},
+	}
+
+	res, err := ServiceRulesToOrchestrationPath(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &OrchestrationPath{
+		Type: OrchestrationPathTypeService,
+		Sets: []*OrchestrationPathSet{
+			{
+				ID: "start",
+				Rules: []*OrchestrationPathRule{
+					{
+						Conditions: []*OrchestrationPathRuleCondition{
+							{Expression: "event.summary matches part 'db' and not event.severity matches 'info'"},
+						},
+						Actions: &OrchestrationPathRuleActions{
+							Severity:    "critical",
+							Priority:    "PPRI1",
+							Extractions: []*OrchestrationPathActionExtraction{{Target: "event.dedup_key", Source: "event.details.host", Regex: "(.*)"}},
+						},
+					},
+					{
+						Disabled: true,
+						Conditions: []*OrchestrationPathRuleCondition{
+							{Expression: `event.source matches regex 'it\'s.*'`},
+							{Expression: "not event.class exists"},
+						},
+						Actions: &OrchestrationPathRuleActions{Suppress: true, Annotate: "noise"},
+					},
+				},
+			},
+		},
+		CatchAll: &OrchestrationPathCatchAll{Actions: &OrchestrationPathRuleActions{}},
+	}
+	testEqual(t, want, res)
+}
+
+// Convert Service Rules without an orchestration equivalent
+func TestServiceRulesToOrchestrationPath_Unsupported(t *testing.T) {
+	tests := []struct {
+		name string
+		rule *ServiceRule
+		want string
+	}{
+		{
+			name: "time_frame",
+			rule: &ServiceRule{TimeFrame: &RuleTimeFrame{}},
+			want: "time frames can't be converted",
+		},
+		{
+			name: "suppress_threshold",
+			rule: &ServiceRule{Actions: &ServiceRuleActions{Suppress: &RuleActionSuppress{Value: true, ThresholdValue: 3}}},
+			want: "suppress thresholds can't be converted",
+		},
+		{
+			name: "suspend",
+			rule: &ServiceRule{Actions: &ServiceRuleActions{Suspend: &RuleActionSuspend{Value: true}}},
+			want: "suspend can't be converted",
+		},
+		{
+			name: "operator",
+			rule: &ServiceRule{Conditions: &RuleConditions{Operator: "and", RuleSubconditions: []*RuleSubcondition{{Operator: "lt", Parameters: &ConditionParameter{}}}}},
+			want: `unsupported subcondition operator "lt"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ServiceRulesToOrchestrationPath([]*ServiceRule{tt.rule})
+			testErrCheck(t, "ServiceRulesToOrchestrationPath()", tt.want, err)
+		})
+	}
+}
+
+// Convert the rules of a Service to Event Orchestration
+func TestEventOrchestration_ConvertServiceRules(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/PSVC1/rules", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Write([]byte(`{"rules": [{"id": "R1", "conditions": {"operator": "and", "subconditions": [{"operator": "exists", "parameters": {"path": "summary"}}]}, "actions": {"event_action": {"value": "resolve"}}}], "more": false}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.ConvertServiceRulesToOrchestration(context.Background(), "PSVC1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule := res.Sets[0].Rules[0]
+	testEqual(t, []*OrchestrationPathRuleCondition{{Expression: "event.summary exists"}}, rule.Conditions)
+	testEqual(t, "resolve", rule.Actions.EventAction)
+}
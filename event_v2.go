@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 )
 
 // V2Event includes the incident/alert details
@@ -43,14 +44,84 @@ type V2EventResponse struct {
 
 const v2eventEndPoint = "https://events.pagerduty.com/v2/enqueue"
 
+// EventsAPIError is returned when the Events API rejects an event or change
+// event. Its shape differs from the REST API's APIError.
+type EventsAPIError struct {
+	// StatusCode is the HTTP response status code
+	StatusCode int `json:"-"`
+
+	Status  string   `json:"status,omitempty"`
+	Message string   `json:"message,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+
+	message string
+}
+
+// Error satisfies the error interface.
+func (e EventsAPIError) Error() string {
+	if len(e.message) > 0 {
+		return e.message
+	}
+
+	msg := fmt.Sprintf("Events API request failed with status code %d, status: %s, message: %s", e.StatusCode, e.Status, e.Message)
+	if len(e.Errors) > 0 {
+		msg += " (" + strings.Join(e.Errors, "; ") + ")"
+	}
+
+	return msg
+}
+
+// RateLimited returns whether the response had a status of 429.
+func (e EventsAPIError) RateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests
+}
+
+// Temporary returns whether it was a temporary error, one of which is a
+// RateLimited error.
+func (e EventsAPIError) Temporary() bool {
+	return e.RateLimited() || (e.StatusCode >= 500 && e.StatusCode < 600)
+}
+
+func (c *Client) checkEventsResponse(resp *http.Response, err error) (*http.Response, error) {
+	if err != nil {
+		return resp, fmt.Errorf("Error calling the API endpoint: %w", err)
+	}
+
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return resp, nil
+	}
+
+	eerr := EventsAPIError{StatusCode: resp.StatusCode}
+
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		eerr.message = fmt.Sprintf("Events API response with status code %d does not contain Content-Type: application/json", resp.StatusCode)
+		_ = resp.Body.Close()
+		return resp, eerr
+	}
+
+	if dErr := c.decodeJSON(resp, &eerr); dErr != nil {
+		eerr.message = fmt.Sprintf("Events API response with status code %d, JSON error object decode failed: %s", resp.StatusCode, dErr)
+	}
+
+	eerr.StatusCode = resp.StatusCode
+
+	return resp, eerr
+}
+
 // ManageEvent handles the trigger, acknowledge, and resolve methods for an event
 func ManageEvent(e V2Event) (*V2EventResponse, error) {
+	return ManageEventWithContext(context.TODO(), e)
+}
+
+// ManageEventWithContext handles the trigger, acknowledge, and resolve methods
+// for an event, sending it to the Events API v2 with e's routing key.
+func ManageEventWithContext(ctx context.Context, e V2Event) (*V2EventResponse, error) {
 	data, err := json.Marshal(e)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, v2eventEndPoint, bytes.NewBuffer(data))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v2eventEndPoint, bytes.NewBuffer(data))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
@@ -80,14 +151,18 @@ func ManageEvent(e V2Event) (*V2EventResponse, error) {
 
 // ManageEvent handles the trigger, acknowledge, and resolve methods for an event
 func (c *Client) ManageEvent(e *V2Event) (*V2EventResponse, error) {
-	headers := make(map[string]string)
+	return c.ManageEventWithContext(context.TODO(), e)
+}
 
+// ManageEventWithContext handles the trigger, acknowledge, and resolve methods
+// for an event, sending it to the client's Events API endpoint.
+func (c *Client) ManageEventWithContext(ctx context.Context, e *V2Event) (*V2EventResponse, error) {
 	data, err := json.Marshal(e)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.doWithEndpoint(context.TODO(), c.v2EventsAPIEndpoint, http.MethodPost, "/v2/enqueue", false, bytes.NewBuffer(data), headers)
+	resp, err := c.doEventsRequest(ctx, "/v2/enqueue", bytes.NewBuffer(data))
 	if err != nil {
 		return nil, err
 	}
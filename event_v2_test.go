@@ -1,6 +1,8 @@
 package pagerduty
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"testing"
 )
@@ -28,3 +30,55 @@ func TestEventV2_ManageEvent(t *testing.T) {
 	}
 	testEqual(t, want, res)
 }
+
+func TestEventV2_ManageEventWithContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/enqueue", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var e V2Event
+		if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+			t.Fatal(err)
+		}
+
+		want := V2Event{
+			RoutingKey: "abc123",
+			Action:     "trigger",
+			DedupKey:   "disk-full",
+			Payload: &V2Payload{
+				Summary:  "Disk full",
+				Source:   "db1",
+				Severity: "critical",
+			},
+		}
+		testEqual(t, want, e)
+
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"status": "success", "dedup_key": "disk-full", "message": "Event processed"}`))
+	})
+	var client = &Client{v2EventsAPIEndpoint: server.URL, apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	evt := &V2Event{
+		RoutingKey: "abc123",
+		Action:     "trigger",
+		DedupKey:   "disk-full",
+		Payload: &V2Payload{
+			Summary:  "Disk full",
+			Source:   "db1",
+			Severity: "critical",
+		},
+	}
+	res, err := client.ManageEventWithContext(context.Background(), evt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &V2EventResponse{
+		Status:   "success",
+		DedupKey: "disk-full",
+		Message:  "Event processed",
+	}
+	testEqual(t, want, res)
+}
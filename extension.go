@@ -8,6 +8,7 @@ import (
 	"github.com/google/go-querystring/query"
 )
 
+// Extension represents a single PagerDuty extension. These are additional features to be used as part of the incident management process.
 type Extension struct {
 	APIObject
 	Name             string      `json:"name"`
@@ -17,25 +18,54 @@ type Extension struct {
 	Config           interface{} `json:"config"`
 }
 
+// ListExtensionResponse represents the single response from PagerDuty API when listing extensions.
 type ListExtensionResponse struct {
 	APIListObject
 	Extensions []Extension `json:"extensions"`
 }
 
+// ListExtensionOptions are the options to use when listing extensions.
 type ListExtensionOptions struct {
 	APIListObject
 	ExtensionObjectID string `url:"extension_object_id,omitempty"`
 	ExtensionSchemaID string `url:"extension_schema_id,omitempty"`
 	Query             string `url:"query,omitempty"`
+
+	// ListAll makes ListExtensions return every extension in a single response,
+	// with Limit as the page size. Offset is ignored, and ListAll isn't sent to
+	// the API.
+	ListAll bool `url:"-"`
 }
 
+// ListExtensions lists the extensions, optionally filtered by the service or extension schema they belong to.
 func (c *Client) ListExtensions(o ListExtensionOptions) (*ListExtensionResponse, error) {
+	return c.ListExtensionsWithContext(context.TODO(), o)
+}
+
+// ListExtensionsWithContext lists the extensions, optionally filtered by the service or extension schema they belong to.
+func (c *Client) ListExtensionsWithContext(ctx context.Context, o ListExtensionOptions) (*ListExtensionResponse, error) {
+	if o.ListAll {
+		var all ListExtensionResponse
+		responseHandler := func(response *http.Response) (APIListObject, error) {
+			var result ListExtensionResponse
+			if err := c.decodeJSON(response, &result); err != nil {
+				return APIListObject{}, err
+			}
+			all.Extensions = append(all.Extensions, result.Extensions...)
+			return result.APIListObject, nil
+		}
+		if err := c.pagedGetAll(ctx, "/extensions", o, responseHandler); err != nil {
+			return nil, err
+		}
+		return &all, nil
+	}
+
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.get(context.TODO(), "/extensions?"+v.Encode())
+	resp, err := c.get(ctx, "/extensions?"+v.Encode())
 	if err != nil {
 		return nil, err
 	}
@@ -45,23 +75,47 @@ func (c *Client) ListExtensions(o ListExtensionOptions) (*ListExtensionResponse,
 	return &result, c.decodeJSON(resp, &result)
 }
 
+// CreateExtension creates a single extension.
 func (c *Client) CreateExtension(e *Extension) (*Extension, error) {
-	resp, err := c.post(context.TODO(), "/extensions", e, nil)
+	return c.CreateExtensionWithContext(context.TODO(), e)
+}
+
+// CreateExtensionWithContext creates a single extension.
+func (c *Client) CreateExtensionWithContext(ctx context.Context, e *Extension) (*Extension, error) {
+	resp, err := c.post(ctx, "/extensions", wrapBody("extension", e), nil)
 	return getExtensionFromResponse(c, resp, err)
 }
 
+// DeleteExtension deletes an extension by its ID.
 func (c *Client) DeleteExtension(id string) error {
-	_, err := c.delete(context.TODO(), "/extensions/"+id)
+	return c.DeleteExtensionWithContext(context.TODO(), id)
+}
+
+// DeleteExtensionWithContext deletes an extension by its ID.
+func (c *Client) DeleteExtensionWithContext(ctx context.Context, id string) error {
+	_, err := c.delete(ctx, "/extensions/"+id)
 	return err
 }
 
+// GetExtension gets a single extension by its ID.
 func (c *Client) GetExtension(id string) (*Extension, error) {
-	resp, err := c.get(context.TODO(), "/extensions/"+id)
+	return c.GetExtensionWithContext(context.TODO(), id)
+}
+
+// GetExtensionWithContext gets a single extension by its ID.
+func (c *Client) GetExtensionWithContext(ctx context.Context, id string) (*Extension, error) {
+	resp, err := c.get(ctx, "/extensions/"+id)
 	return getExtensionFromResponse(c, resp, err)
 }
 
+// UpdateExtension updates an extension by its ID.
 func (c *Client) UpdateExtension(id string, e *Extension) (*Extension, error) {
-	resp, err := c.put(context.TODO(), "/extensions/"+id, e, nil)
+	return c.UpdateExtensionWithContext(context.TODO(), id, e)
+}
+
+// UpdateExtensionWithContext updates an extension by its ID.
+func (c *Client) UpdateExtensionWithContext(ctx context.Context, id string, e *Extension) (*Extension, error) {
+	resp, err := c.put(ctx, "/extensions/"+id, wrapBody("extension", e), nil)
 	return getExtensionFromResponse(c, resp, err)
 }
 
@@ -8,6 +8,8 @@ import (
 	"github.com/google/go-querystring/query"
 )
 
+// ExtensionSchema represents the object presented by the API for each extension
+// schema, describing an outbound integration such as Slack or ServiceNow.
 type ExtensionSchema struct {
 	APIObject
 	IconURL     string   `json:"icon_url"`
@@ -20,23 +22,56 @@ type ExtensionSchema struct {
 	URL         string   `json:"url"`
 }
 
+// ListExtensionSchemaResponse is the object presented in response to the
+// request to list all extension schemas.
 type ListExtensionSchemaResponse struct {
 	APIListObject
 	ExtensionSchemas []ExtensionSchema `json:"extension_schemas"`
 }
 
+// ListExtensionSchemaOptions are the options to send with the
+// ListExtensionSchema request(s).
 type ListExtensionSchemaOptions struct {
 	APIListObject
 	Query string `url:"query,omitempty"`
+
+	// ListAll makes ListExtensionSchemas return every extension schema in a single
+	// response, with Limit as the page size. Offset is ignored, and ListAll isn't
+	// sent to the API.
+	ListAll bool `url:"-"`
 }
 
+// ListExtensionSchemas lists all of the extension schemas. Each schema
+// represents a specific type of outbound extension.
 func (c *Client) ListExtensionSchemas(o ListExtensionSchemaOptions) (*ListExtensionSchemaResponse, error) {
+	return c.ListExtensionSchemasWithContext(context.TODO(), o)
+}
+
+// ListExtensionSchemasWithContext lists all of the extension schemas. Each schema
+// represents a specific type of outbound extension.
+func (c *Client) ListExtensionSchemasWithContext(ctx context.Context, o ListExtensionSchemaOptions) (*ListExtensionSchemaResponse, error) {
+	if o.ListAll {
+		var all ListExtensionSchemaResponse
+		responseHandler := func(response *http.Response) (APIListObject, error) {
+			var result ListExtensionSchemaResponse
+			if err := c.decodeJSON(response, &result); err != nil {
+				return APIListObject{}, err
+			}
+			all.ExtensionSchemas = append(all.ExtensionSchemas, result.ExtensionSchemas...)
+			return result.APIListObject, nil
+		}
+		if err := c.pagedGetAll(ctx, "/extension_schemas", o, responseHandler); err != nil {
+			return nil, err
+		}
+		return &all, nil
+	}
+
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.get(context.TODO(), "/extension_schemas?"+v.Encode())
+	resp, err := c.get(ctx, "/extension_schemas?"+v.Encode())
 	if err != nil {
 		return nil, err
 	}
@@ -46,8 +81,14 @@ func (c *Client) ListExtensionSchemas(o ListExtensionSchemaOptions) (*ListExtens
 	return &result, c.decodeJSON(resp, &result)
 }
 
+// GetExtensionSchema gets a single extension schema.
 func (c *Client) GetExtensionSchema(id string) (*ExtensionSchema, error) {
-	resp, err := c.get(context.TODO(), "/extension_schemas/"+id)
+	return c.GetExtensionSchemaWithContext(context.TODO(), id)
+}
+
+// GetExtensionSchemaWithContext gets a single extension schema.
+func (c *Client) GetExtensionSchemaWithContext(ctx context.Context, id string) (*ExtensionSchema, error) {
+	resp, err := c.get(ctx, "/extension_schemas/"+id)
 	return getExtensionSchemaFromResponse(c, resp, err)
 }
 
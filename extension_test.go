@@ -1,6 +1,7 @@
 package pagerduty
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"testing"
@@ -52,6 +53,34 @@ func TestExtension_List(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+func TestExtension_ListWithContextForService(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/extensions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "PSVC1", r.URL.Query().Get("extension_object_id"))
+		w.Write([]byte(`{"extensions":[{"id":"1","endpoint_url":"https://example.com/hook","extension_schema":{"id":"PSCHEMA1","type":"extension_schema_reference"},"extension_objects":[{"id":"PSVC1","type":"service_reference"}]}]}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.ListExtensionsWithContext(context.Background(), ListExtensionOptions{ExtensionObjectID: "PSVC1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Extension{
+		{
+			APIObject:        APIObject{ID: "1"},
+			EndpointURL:      "https://example.com/hook",
+			ExtensionSchema:  APIObject{ID: "PSCHEMA1", Type: "extension_schema_reference"},
+			ExtensionObjects: []APIObject{{ID: "PSVC1", Type: "service_reference"}},
+		},
+	}
+	testEqual(t, want, res.Extensions)
+}
+
 func TestExtension_Create(t *testing.T) {
 	setup()
 	defer teardown()
@@ -60,11 +89,12 @@ func TestExtension_Create(t *testing.T) {
 	input2 := &Extension{Name: "bar", EndpointURL: "expected_url"}
 
 	mux.HandleFunc("/extensions", func(w http.ResponseWriter, r *http.Request) {
-		var got map[string]interface{}
+		var body map[string]map[string]interface{}
 
-		err := json.NewDecoder(r.Body).Decode(&got)
+		err := json.NewDecoder(r.Body).Decode(&body)
 
 		testErrCheck(t, "Extension_Create()", "", err)
+		got := body["extension"]
 		name := got["name"]
 
 		if name == "foo" {
@@ -158,11 +188,12 @@ func TestExtension_Update(t *testing.T) {
 	input2 := &Extension{Name: "foo", EndpointURL: "expected_url"}
 
 	mux.HandleFunc("/extensions/1", func(w http.ResponseWriter, r *http.Request) {
-		var got map[string]interface{}
+		var body map[string]map[string]interface{}
 
-		err := json.NewDecoder(r.Body).Decode(&got)
+		err := json.NewDecoder(r.Body).Decode(&body)
 
 		testErrCheck(t, "Extension_Update()", "", err)
+		got := body["extension"]
 		testNoEndpointURL(t, got)
 
 		testMethod(t, r, "PUT")
@@ -170,10 +201,11 @@ func TestExtension_Update(t *testing.T) {
 	})
 
 	mux.HandleFunc("/extensions/2", func(w http.ResponseWriter, r *http.Request) {
-		var got map[string]interface{}
+		var body map[string]map[string]interface{}
 
-		err := json.NewDecoder(r.Body).Decode(&got)
+		err := json.NewDecoder(r.Body).Decode(&body)
 		testErrCheck(t, "Extension_Update()", "", err)
+		got := body["extension"]
 
 		testGotExpectedURL(t, "expected_url", got)
 
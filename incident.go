@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -112,6 +113,11 @@ type ListIncidentsOptions struct {
 	TimeZone    string   `url:"time_zone,omitempty"`
 	SortBy      string   `url:"sort_by,omitempty"`
 	Includes    []string `url:"include,omitempty,brackets"`
+
+	// ListAll makes ListIncidents return every incident in a single response, with
+	// Limit as the page size. Offset is ignored, and ListAll isn't sent to the
+	// API.
+	ListAll bool `url:"-"`
 }
 
 // ConferenceBridge is a struct for the conference_bridge object on an incident
@@ -122,11 +128,32 @@ type ConferenceBridge struct {
 
 // ListIncidents lists existing incidents.
 func (c *Client) ListIncidents(o ListIncidentsOptions) (*ListIncidentsResponse, error) {
+	return c.ListIncidentsWithContext(context.TODO(), o)
+}
+
+// ListIncidentsWithContext lists existing incidents.
+func (c *Client) ListIncidentsWithContext(ctx context.Context, o ListIncidentsOptions) (*ListIncidentsResponse, error) {
+	if o.ListAll {
+		var all ListIncidentsResponse
+		responseHandler := func(response *http.Response) (APIListObject, error) {
+			var result ListIncidentsResponse
+			if err := c.decodeJSON(response, &result); err != nil {
+				return APIListObject{}, err
+			}
+			all.Incidents = append(all.Incidents, result.Incidents...)
+			return result.APIListObject, nil
+		}
+		if err := c.pagedGetAll(ctx, "/incidents", o, responseHandler); err != nil {
+			return nil, err
+		}
+		return &all, nil
+	}
+
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.get(context.TODO(), "/incidents?"+v.Encode())
+	resp, err := c.get(ctx, "/incidents?"+v.Encode())
 	if err != nil {
 		return nil, err
 	}
@@ -134,6 +161,82 @@ func (c *Client) ListIncidents(o ListIncidentsOptions) (*ListIncidentsResponse,
 	return &result, c.decodeJSON(resp, &result)
 }
 
+// GetServiceOpenIncidentCount gets the number of triggered and acknowledged
+// incidents on the service serviceID, however long ago they were created. It
+// makes a single request for one incident and only reads the total the API
+// returns.
+func (c *Client) GetServiceOpenIncidentCount(ctx context.Context, serviceID string) (int, error) {
+	o := ListIncidentsOptions{
+		APIListObject: APIListObject{Limit: 1},
+		DateRange:     "all",
+		Statuses:      []string{"triggered", "acknowledged"},
+		ServiceIDs:    []string{serviceID},
+	}
+	v, err := query.Values(o)
+	if err != nil {
+		return 0, err
+	}
+	// the total is only computed when asked for, and APIListObject can't
+	// send it as a boolean
+	v.Set("total", "true")
+
+	resp, err := c.get(ctx, "/incidents?"+v.Encode())
+	if err != nil {
+		return 0, err
+	}
+	var result ListIncidentsResponse
+	if err := c.decodeJSON(resp, &result); err != nil {
+		return 0, err
+	}
+	return int(result.Total), nil
+}
+
+// MergeResolveReasonType is the ResolveReason type of an incident that was
+// resolved by being merged into another incident.
+const MergeResolveReasonType = "merge_resolve_reason"
+
+// ListMergedIncidents lists the incidents that were merged into the incident
+// parentID. The API can't filter incidents by merge parent, so this pages
+// through the resolved incidents matched by o and keeps those whose resolve
+// reason points at parentID; use o's Since, Until, and ServiceIDs to narrow
+// the search. o.Statuses is ignored.
+func (c *Client) ListMergedIncidents(ctx context.Context, parentID string, o ListIncidentsOptions) ([]Incident, error) {
+	o.Statuses = []string{"resolved"}
+	o.Offset = 0
+
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	incidents := make([]Incident, 0)
+
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		var result ListIncidentsResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return APIListObject{}, err
+		}
+
+		for _, i := range result.Incidents {
+			if i.ResolveReason.Type == MergeResolveReasonType && i.ResolveReason.Incident.ID == parentID {
+				incidents = append(incidents, i)
+			}
+		}
+
+		return APIListObject{
+			More:   result.More,
+			Offset: result.Offset,
+			Limit:  result.Limit,
+		}, nil
+	}
+
+	if err := c.pagedGet(ctx, "/incidents?"+v.Encode(), responseHandler); err != nil {
+		return nil, err
+	}
+
+	return incidents, nil
+}
+
 // createIncidentResponse is returned from the API when creating a response.
 type createIncidentResponse struct {
 	Incident Incident `json:"incident"`
@@ -169,19 +272,28 @@ type MergeIncidentsOptions struct {
 
 // CreateIncident creates an incident synchronously without a corresponding event from a monitoring service.
 func (c *Client) CreateIncident(from string, o *CreateIncidentOptions) (*Incident, error) {
+	return c.CreateIncidentWithContext(context.TODO(), from, o)
+}
+
+// CreateIncidentWithContext creates an incident synchronously without a
+// corresponding event from a monitoring service. from is the email address of
+// a valid user, which the API requires.
+func (c *Client) CreateIncidentWithContext(ctx context.Context, from string, o *CreateIncidentOptions) (*Incident, error) {
+	from, err := c.resolveFrom(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+
 	headers := make(map[string]string)
 	headers["From"] = from
-	data := make(map[string]*CreateIncidentOptions)
-	data["incident"] = o
-	resp, e := c.post(context.TODO(), "/incidents", data, headers)
-	if e != nil {
-		return nil, e
+	resp, err := c.post(ctx, "/incidents", wrapBody("incident", o), headers)
+	if err != nil {
+		return nil, err
 	}
 
 	var ii createIncidentResponse
-	e = json.NewDecoder(resp.Body).Decode(&ii)
-	if e != nil {
-		return nil, e
+	if err := c.decodeJSON(resp, &ii); err != nil {
+		return nil, err
 	}
 
 	return &ii.Incident, nil
@@ -189,12 +301,22 @@ func (c *Client) CreateIncident(from string, o *CreateIncidentOptions) (*Inciden
 
 // ManageIncidents acknowledges, resolves, escalates, or reassigns one or more incidents.
 func (c *Client) ManageIncidents(from string, incidents []ManageIncidentsOptions) (*ListIncidentsResponse, error) {
-	data := make(map[string][]ManageIncidentsOptions)
+	return c.ManageIncidentsWithContext(context.TODO(), from, incidents)
+}
+
+// ManageIncidentsWithContext acknowledges, resolves, escalates, or reassigns
+// one or more incidents. from is the email address of a valid user, which the
+// API requires.
+func (c *Client) ManageIncidentsWithContext(ctx context.Context, from string, incidents []ManageIncidentsOptions) (*ListIncidentsResponse, error) {
+	from, err := c.resolveFrom(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+
 	headers := make(map[string]string)
 	headers["From"] = from
-	data["incidents"] = incidents
 
-	resp, err := c.put(context.TODO(), "/incidents", data, headers)
+	resp, err := c.put(ctx, "/incidents", wrapBody("incidents", incidents), headers)
 	if err != nil {
 		return nil, err
 	}
@@ -204,12 +326,22 @@ func (c *Client) ManageIncidents(from string, incidents []ManageIncidentsOptions
 
 // MergeIncidents a list of source incidents into a specified incident.
 func (c *Client) MergeIncidents(from string, id string, sourceIncidents []MergeIncidentsOptions) (*Incident, error) {
-	r := make(map[string][]MergeIncidentsOptions)
-	r["source_incidents"] = sourceIncidents
+	return c.MergeIncidentsWithContext(context.TODO(), from, id, sourceIncidents)
+}
+
+// MergeIncidentsWithContext merges a list of source incidents into a specified
+// incident, resolving them and moving their alerts to it. from is the email
+// address of a valid user, which the API requires.
+func (c *Client) MergeIncidentsWithContext(ctx context.Context, from string, id string, sourceIncidents []MergeIncidentsOptions) (*Incident, error) {
+	from, err := c.resolveFrom(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+
 	headers := make(map[string]string)
 	headers["From"] = from
 
-	resp, err := c.put(context.TODO(), "/incidents/"+id+"/merge", r, headers)
+	resp, err := c.put(ctx, "/incidents/"+id+"/merge", wrapBody("source_incidents", sourceIncidents), headers)
 	if err != nil {
 		return nil, err
 	}
@@ -217,9 +349,29 @@ func (c *Client) MergeIncidents(from string, id string, sourceIncidents []MergeI
 	return &result.Incident, c.decodeJSON(resp, &result)
 }
 
+// MergeIncidentsByID merges the incidents with the IDs sourceIncidentIDs into
+// the incident targetIncidentID, and returns the updated target incident.
+func (c *Client) MergeIncidentsByID(ctx context.Context, from, targetIncidentID string, sourceIncidentIDs []string) (*Incident, error) {
+	if len(sourceIncidentIDs) == 0 {
+		return nil, fmt.Errorf("no source incidents to merge into %s", targetIncidentID)
+	}
+
+	sources := make([]MergeIncidentsOptions, len(sourceIncidentIDs))
+	for i, id := range sourceIncidentIDs {
+		sources[i] = MergeIncidentsOptions{ID: id, Type: "incident_reference"}
+	}
+
+	return c.MergeIncidentsWithContext(ctx, from, targetIncidentID, sources)
+}
+
 // GetIncident shows detailed information about an incident.
 func (c *Client) GetIncident(id string) (*Incident, error) {
-	resp, err := c.get(context.TODO(), "/incidents/"+id)
+	return c.GetIncidentWithContext(context.TODO(), id)
+}
+
+// GetIncidentWithContext shows detailed information about an incident.
+func (c *Client) GetIncidentWithContext(ctx context.Context, id string) (*Incident, error) {
+	resp, err := c.get(ctx, "/incidents/"+id)
 	if err != nil {
 		return nil, err
 	}
@@ -249,7 +401,12 @@ type CreateIncidentNoteResponse struct {
 
 // ListIncidentNotes lists existing notes for the specified incident.
 func (c *Client) ListIncidentNotes(id string) ([]IncidentNote, error) {
-	resp, err := c.get(context.TODO(), "/incidents/"+id+"/notes")
+	return c.ListIncidentNotesWithContext(context.TODO(), id)
+}
+
+// ListIncidentNotesWithContext lists existing notes for the specified incident.
+func (c *Client) ListIncidentNotesWithContext(ctx context.Context, id string) ([]IncidentNote, error) {
+	resp, err := c.get(ctx, "/incidents/"+id+"/notes")
 	if err != nil {
 		return nil, err
 	}
@@ -267,15 +424,39 @@ func (c *Client) ListIncidentNotes(id string) ([]IncidentNote, error) {
 // IncidentAlert is a alert for the specified incident.
 type IncidentAlert struct {
 	APIObject
-	CreatedAt   string                 `json:"created_at,omitempty"`
-	Status      string                 `json:"status,omitempty"`
-	AlertKey    string                 `json:"alert_key,omitempty"`
-	Service     APIObject              `json:"service,omitempty"`
-	Body        map[string]interface{} `json:"body,omitempty"`
-	Incident    APIReference           `json:"incident,omitempty"`
-	Suppressed  bool                   `json:"suppressed,omitempty"`
-	Severity    string                 `json:"severity,omitempty"`
-	Integration APIObject              `json:"integration,omitempty"`
+	CreatedAt   string             `json:"created_at,omitempty"`
+	Status      string             `json:"status,omitempty"`
+	AlertKey    string             `json:"alert_key,omitempty"`
+	Service     APIObject          `json:"service,omitempty"`
+	Body        *IncidentAlertBody `json:"body,omitempty"`
+	Incident    APIReference       `json:"incident,omitempty"`
+	Suppressed  bool               `json:"suppressed,omitempty"`
+	Severity    string             `json:"severity,omitempty"`
+	Integration APIObject          `json:"integration,omitempty"`
+}
+
+// IncidentAlertBody is the body of the event an alert was created from.
+type IncidentAlertBody struct {
+	Type     string                 `json:"type,omitempty"`
+	Contexts []IncidentAlertContext `json:"contexts,omitempty"`
+
+	// Details are the event's custom details, as sent in the payload's
+	// custom_details field of an Events API v2 event.
+	Details map[string]interface{} `json:"details,omitempty"`
+
+	// CEFDetails is the event normalized to the PagerDuty Common Event
+	// Format.
+	CEFDetails map[string]interface{} `json:"cef_details,omitempty"`
+}
+
+// IncidentAlertContext is a link or image attached to the event an alert was
+// created from.
+type IncidentAlertContext struct {
+	Type string `json:"type,omitempty"`
+	Href string `json:"href,omitempty"`
+	Text string `json:"text,omitempty"`
+	Src  string `json:"src,omitempty"`
+	Alt  string `json:"alt,omitempty"`
 }
 
 // IncidentAlertResponse is the response of a sincle incident alert
@@ -300,6 +481,11 @@ type ListIncidentAlertsOptions struct {
 	Statuses []string `url:"statuses,omitempty,brackets"`
 	SortBy   string   `url:"sort_by,omitempty"`
 	Includes []string `url:"include,omitempty,brackets"`
+
+	// ListAll makes ListIncidentAlerts return every alert of the incident in a
+	// single response, with Limit as the page size. Offset is ignored, and ListAll
+	// isn't sent to the API.
+	ListAll bool `url:"-"`
 }
 
 // ListIncidentAlerts lists existing alerts for the specified incident.
@@ -309,11 +495,32 @@ func (c *Client) ListIncidentAlerts(id string) (*ListAlertsResponse, error) {
 
 // ListIncidentAlertsWithOpts lists existing alerts for the specified incident.
 func (c *Client) ListIncidentAlertsWithOpts(id string, o ListIncidentAlertsOptions) (*ListAlertsResponse, error) {
+	return c.ListIncidentAlertsWithContext(context.TODO(), id, o)
+}
+
+// ListIncidentAlertsWithContext lists existing alerts for the specified incident.
+func (c *Client) ListIncidentAlertsWithContext(ctx context.Context, id string, o ListIncidentAlertsOptions) (*ListAlertsResponse, error) {
+	if o.ListAll {
+		var all ListAlertsResponse
+		responseHandler := func(response *http.Response) (APIListObject, error) {
+			var result ListAlertsResponse
+			if err := c.decodeJSON(response, &result); err != nil {
+				return APIListObject{}, err
+			}
+			all.Alerts = append(all.Alerts, result.Alerts...)
+			return result.APIListObject, nil
+		}
+		if err := c.pagedGetAll(ctx, "/incidents/"+id+"/alerts", o, responseHandler); err != nil {
+			return nil, err
+		}
+		return &all, nil
+	}
+
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.get(context.TODO(), "/incidents/"+id+"/alerts?"+v.Encode())
+	resp, err := c.get(ctx, "/incidents/"+id+"/alerts?"+v.Encode())
 	if err != nil {
 		return nil, err
 	}
@@ -324,12 +531,10 @@ func (c *Client) ListIncidentAlertsWithOpts(id string, o ListIncidentAlertsOptio
 
 // CreateIncidentNoteWithResponse creates a new note for the specified incident.
 func (c *Client) CreateIncidentNoteWithResponse(id string, note IncidentNote) (*IncidentNote, error) {
-	data := make(map[string]IncidentNote)
 	headers := make(map[string]string)
 	headers["From"] = note.User.Summary
 
-	data["note"] = note
-	resp, err := c.post(context.TODO(), "/incidents/"+id+"/notes", data, headers)
+	resp, err := c.post(context.TODO(), "/incidents/"+id+"/notes", wrapBody("note", note), headers)
 	if err != nil {
 		return nil, err
 	}
@@ -343,14 +548,76 @@ func (c *Client) CreateIncidentNoteWithResponse(id string, note IncidentNote) (*
 	return &result.IncidentNote, nil
 }
 
+// CreateIncidentNoteWithContext creates a new note for the specified
+// incident, authored by the user with the email address from.
+func (c *Client) CreateIncidentNoteWithContext(ctx context.Context, from, incidentID string, note IncidentNote) (*IncidentNote, error) {
+	from, err := c.resolveFrom(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make(map[string]string)
+	headers["From"] = from
+
+	resp, err := c.post(ctx, "/incidents/"+incidentID+"/notes", wrapBody("note", note), headers)
+	if err != nil {
+		return nil, err
+	}
+
+	var result CreateIncidentNoteResponse
+	if err := c.decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.IncidentNote, nil
+}
+
+// IncidentStatusUpdate is a status update sent to the stakeholders of an
+// incident.
+type IncidentStatusUpdate struct {
+	ID        string    `json:"id,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	CreatedAt string    `json:"created_at,omitempty"`
+	Sender    APIObject `json:"sender,omitempty"`
+}
+
+// CreateIncidentStatusUpdate sends a status update to the stakeholders of an
+// incident, on behalf of the user with the email address from.
+func (c *Client) CreateIncidentStatusUpdate(ctx context.Context, from, incidentID, message string) (*IncidentStatusUpdate, error) {
+	from, err := c.resolveFrom(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make(map[string]string)
+	headers["From"] = from
+
+	data := map[string]string{"message": message}
+
+	resp, err := c.post(ctx, "/incidents/"+incidentID+"/status_updates", data, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	var result map[string]IncidentStatusUpdate
+	if err := c.decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	u, ok := result["status_update"]
+	if !ok {
+		return nil, fmt.Errorf("JSON response does not have status_update field")
+	}
+
+	return &u, nil
+}
+
 // CreateIncidentNote creates a new note for the specified incident.
 // DEPRECATED: please use CreateIncidentNoteWithResponse going forward
 func (c *Client) CreateIncidentNote(id string, note IncidentNote) error {
-	data := make(map[string]IncidentNote)
 	headers := make(map[string]string)
 	headers["From"] = note.User.Summary
-	data["note"] = note
-	_, err := c.post(context.TODO(), "/incidents/"+id+"/notes", data, headers)
+	_, err := c.post(context.TODO(), "/incidents/"+id+"/notes", wrapBody("note", note), headers)
 	return err
 }
 
@@ -380,6 +647,48 @@ func (c *Client) SnoozeIncident(id string, duration uint) error {
 	return err
 }
 
+// SnoozeIncidentUntil sets the incident incidentID to not alert until the
+// specified time, on behalf of the user whose email address is from. The
+// snooze duration is computed from the current time, and an error is returned
+// if until is not in the future.
+func (c *Client) SnoozeIncidentUntil(ctx context.Context, incidentID, from string, until time.Time) (*Incident, error) {
+	d := time.Until(until)
+	if d <= 0 {
+		return nil, fmt.Errorf("snooze time %s is not in the future", until.Format(time.RFC3339))
+	}
+
+	// round up so the incident stays snoozed until at least the requested time
+	duration := uint((d + time.Second - 1) / time.Second)
+
+	return c.SnoozeIncidentWithContext(ctx, from, incidentID, duration)
+}
+
+// SnoozeIncidentWithContext sets an incident to not alert for duration
+// seconds, which must be greater than zero. from is the email address of a
+// valid user, which the API requires.
+func (c *Client) SnoozeIncidentWithContext(ctx context.Context, from, id string, duration uint) (*Incident, error) {
+	if duration == 0 {
+		return nil, fmt.Errorf("snooze duration must be greater than zero")
+	}
+
+	from, err := c.resolveFrom(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]uint)
+	data["duration"] = duration
+	headers := make(map[string]string)
+	headers["From"] = from
+
+	resp, err := c.post(ctx, "/incidents/"+id+"/snooze", data, headers)
+	if err != nil {
+		return nil, err
+	}
+	var result createIncidentResponse
+	return &result.Incident, c.decodeJSON(resp, &result)
+}
+
 // ListIncidentLogEntriesResponse is the response structure when calling the ListIncidentLogEntries API endpoint.
 type ListIncidentLogEntriesResponse struct {
 	APIListObject
@@ -394,15 +703,41 @@ type ListIncidentLogEntriesOptions struct {
 	TimeZone   string   `url:"time_zone,omitempty"`
 	Since      string   `url:"since,omitempty"`
 	Until      string   `url:"until,omitempty"`
+
+	// ListAll makes ListIncidentLogEntries return every log entry of the incident
+	// in a single response, with Limit as the page size. Offset is ignored, and
+	// ListAll isn't sent to the API.
+	ListAll bool `url:"-"`
 }
 
 // ListIncidentLogEntries lists existing log entries for the specified incident.
 func (c *Client) ListIncidentLogEntries(id string, o ListIncidentLogEntriesOptions) (*ListIncidentLogEntriesResponse, error) {
+	return c.ListIncidentLogEntriesWithContext(context.TODO(), id, o)
+}
+
+// ListIncidentLogEntriesWithContext lists existing log entries for the specified incident.
+func (c *Client) ListIncidentLogEntriesWithContext(ctx context.Context, id string, o ListIncidentLogEntriesOptions) (*ListIncidentLogEntriesResponse, error) {
+	if o.ListAll {
+		var all ListIncidentLogEntriesResponse
+		responseHandler := func(response *http.Response) (APIListObject, error) {
+			var result ListIncidentLogEntriesResponse
+			if err := c.decodeJSON(response, &result); err != nil {
+				return APIListObject{}, err
+			}
+			all.LogEntries = append(all.LogEntries, result.LogEntries...)
+			return result.APIListObject, nil
+		}
+		if err := c.pagedGetAll(ctx, "/incidents/"+id+"/log_entries", o, responseHandler); err != nil {
+			return nil, err
+		}
+		return &all, nil
+	}
+
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.get(context.TODO(), "/incidents/"+id+"/log_entries?"+v.Encode())
+	resp, err := c.get(ctx, "/incidents/"+id+"/log_entries?"+v.Encode())
 	if err != nil {
 		return nil, err
 	}
@@ -410,6 +745,50 @@ func (c *Client) ListIncidentLogEntries(id string, o ListIncidentLogEntriesOptio
 	return &result, c.decodeJSON(resp, &result)
 }
 
+// PastIncident is an incident on the same service that PagerDuty considers
+// similar to another incident, along with how similar it is.
+type PastIncident struct {
+	Incident Incident `json:"incident"`
+	Score    float64  `json:"score"`
+}
+
+// ListPastIncidentsResponse is the response structure when calling the ListPastIncidents API endpoint.
+type ListPastIncidentsResponse struct {
+	Limit         uint           `json:"limit,omitempty"`
+	Total         uint           `json:"total,omitempty"`
+	PastIncidents []PastIncident `json:"past_incidents"`
+}
+
+// listPastIncidentsOptions is the structure used when passing parameters to
+// the ListPastIncidents API endpoint.
+type listPastIncidentsOptions struct {
+	Limit int  `url:"limit,omitempty"`
+	Total bool `url:"total,omitempty"`
+}
+
+// ListPastIncidents lists incidents from the past six months that are similar
+// to the specified incident, most similar first. The endpoint is not
+// paginated; limit caps the number of results (the API defaults to 5 when it
+// is zero) and total requests that the total number of matches be populated.
+func (c *Client) ListPastIncidents(ctx context.Context, incidentID string, limit int, total bool) (*ListPastIncidentsResponse, error) {
+	if limit < 0 {
+		return nil, fmt.Errorf("limit must not be negative, got %d", limit)
+	}
+
+	v, err := query.Values(listPastIncidentsOptions{Limit: limit, Total: total})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.get(ctx, "/incidents/"+incidentID+"/past_incidents?"+v.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListPastIncidentsResponse
+	return &result, c.decodeJSON(resp, &result)
+}
+
 // IncidentResponders contains details about responders to an incident.
 type IncidentResponders struct {
 	State       string    `json:"state"`
@@ -456,8 +835,13 @@ type ResponderRequest struct {
 
 // ResponderRequest will submit a request to have a responder join an incident.
 func (c *Client) ResponderRequest(id string, o ResponderRequestOptions) (*ResponderRequestResponse, error) {
+	from, err := c.resolveFrom(context.TODO(), o.From)
+	if err != nil {
+		return nil, err
+	}
+
 	headers := make(map[string]string)
-	headers["From"] = o.From
+	headers["From"] = from
 
 	resp, err := c.post(context.TODO(), "/incidents/"+id+"/responder_requests", o, headers)
 	if err != nil {
@@ -469,28 +853,114 @@ func (c *Client) ResponderRequest(id string, o ResponderRequestOptions) (*Respon
 	return result, err
 }
 
+// IncidentResponderRequest is a responder request as returned by
+// CreateIncidentResponderRequest, with the state of the responders each
+// target paged.
+type IncidentResponderRequest struct {
+	Incident    APIObject                         `json:"incident"`
+	Requester   APIObject                         `json:"requester"`
+	RequestedAt string                            `json:"requested_at,omitempty"`
+	Message     string                            `json:"message,omitempty"`
+	Targets     []IncidentResponderRequestTargets `json:"responder_request_targets"`
+}
+
+// IncidentResponderRequestTargets is a wrapper for an
+// IncidentResponderRequestTarget.
+type IncidentResponderRequestTargets struct {
+	Target IncidentResponderRequestTarget `json:"responder_request_target"`
+}
+
+// IncidentResponderRequestTarget is a user or escalation policy a responder
+// request was sent to, and the incident responders it added: the user itself,
+// or the users on call for the escalation policy.
+type IncidentResponderRequestTarget struct {
+	APIObject
+	Responders []IncidentResponders `json:"incident_responders"`
+}
+
+// CreateIncidentResponderRequest asks the users and escalation policies in
+// o.Targets to join an incident as responders. Only the ID and Type of each
+// target are sent, and from, the email address of a valid user, is used
+// instead of o.From.
+func (c *Client) CreateIncidentResponderRequest(ctx context.Context, from, incidentID string, o ResponderRequestOptions) (*IncidentResponderRequest, error) {
+	from, err := c.resolveFrom(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make(map[string]string)
+	headers["From"] = from
+
+	targets := make([]map[string]APIObject, len(o.Targets))
+	for i, t := range o.Targets {
+		targets[i] = map[string]APIObject{
+			"responder_request_target": {ID: t.ID, Type: t.Type},
+		}
+	}
+
+	d := map[string]interface{}{
+		"requester_id":              o.RequesterID,
+		"message":                   o.Message,
+		"responder_request_targets": targets,
+	}
+
+	resp, err := c.post(ctx, "/incidents/"+incidentID+"/responder_requests", d, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	var result map[string]IncidentResponderRequest
+	if err := c.decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	rr, ok := result["responder_request"]
+	if !ok {
+		return nil, fmt.Errorf("JSON response does not have responder_request field")
+	}
+
+	return &rr, nil
+}
+
 // GetIncidentAlert
 func (c *Client) GetIncidentAlert(incidentID, alertID string) (*IncidentAlertResponse, *http.Response, error) {
-	resp, err := c.get(context.TODO(), "/incidents/"+incidentID+"/alerts/"+alertID)
+	return c.GetIncidentAlertWithContext(context.TODO(), incidentID, alertID)
+}
+
+// GetIncidentAlertWithContext gets an alert of the specified incident.
+func (c *Client) GetIncidentAlertWithContext(ctx context.Context, incidentID, alertID string) (*IncidentAlertResponse, *http.Response, error) {
+	resp, err := c.get(ctx, "/incidents/"+incidentID+"/alerts/"+alertID)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	result := &IncidentAlertResponse{}
-	err = json.NewDecoder(resp.Body).Decode(result)
-	return result, resp, err
+	return result, resp, c.decodeJSON(resp, result)
 }
 
-// ManageIncidentAlerts
+// ManageIncidentAlerts updates alerts of the specified incident. The From
+// header is set from the client's default, see WithDefaultFrom.
 func (c *Client) ManageIncidentAlerts(incidentID string, alerts *IncidentAlertList) (*ListAlertsResponse, *http.Response, error) {
+	return c.ManageIncidentAlertsWithContext(context.TODO(), "", incidentID, alerts)
+}
+
+// ManageIncidentAlertsWithContext updates alerts of the specified incident,
+// for example to resolve them by setting their Status to "resolved", or to
+// move them to another incident by setting their Incident reference. from is
+// the email address of a valid user, which the API requires.
+func (c *Client) ManageIncidentAlertsWithContext(ctx context.Context, from, incidentID string, alerts *IncidentAlertList) (*ListAlertsResponse, *http.Response, error) {
+	from, err := c.resolveFrom(ctx, from)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	headers := make(map[string]string)
+	headers["From"] = from
 
-	resp, err := c.put(context.TODO(), "/incidents/"+incidentID+"/alerts/", alerts, headers)
+	resp, err := c.put(ctx, "/incidents/"+incidentID+"/alerts/", alerts, headers)
 	if err != nil {
 		return nil, nil, err
 	}
 	var result ListAlertsResponse
 	return &result, resp, c.decodeJSON(resp, &result)
 }
-
-/* TODO: Create Status Updates */
@@ -0,0 +1,158 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// The values accepted by the DataType field of IncidentCustomField.
+const (
+	IncidentCustomFieldDataTypeBoolean  = "boolean"
+	IncidentCustomFieldDataTypeInteger  = "integer"
+	IncidentCustomFieldDataTypeFloat    = "float"
+	IncidentCustomFieldDataTypeString   = "string"
+	IncidentCustomFieldDataTypeDateTime = "datetime"
+	IncidentCustomFieldDataTypeURL      = "url"
+)
+
+// The values accepted by the FieldType field of IncidentCustomField. The
+// fixed types only accept the values in the field's FieldOptions, which is
+// how single and multi-select fields are made.
+const (
+	IncidentCustomFieldTypeSingleValue      = "single_value"
+	IncidentCustomFieldTypeSingleValueFixed = "single_value_fixed"
+	IncidentCustomFieldTypeMultiValue       = "multi_value"
+	IncidentCustomFieldTypeMultiValueFixed  = "multi_value_fixed"
+)
+
+// IncidentCustomField is a custom field that incidents of the account can
+// have a value for.
+type IncidentCustomField struct {
+	ID          string `json:"id,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Description string `json:"description,omitempty"`
+	DataType    string `json:"data_type"`
+	FieldType   string `json:"field_type"`
+
+	// DefaultValue is the field's value on new incidents, decoded like the
+	// Value of IncidentCustomFieldValue.
+	DefaultValue interface{} `json:"default_value,omitempty"`
+
+	// FieldOptions are the values a fixed field accepts.
+	FieldOptions []IncidentCustomFieldOption `json:"field_options,omitempty"`
+
+	CreatedAt string `json:"created_at,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// IncidentCustomFieldOption is one of the values a fixed custom field accepts.
+type IncidentCustomFieldOption struct {
+	ID   string                        `json:"id,omitempty"`
+	Type string                        `json:"type,omitempty"`
+	Data IncidentCustomFieldOptionData `json:"data"`
+}
+
+// IncidentCustomFieldOptionData is the value of an IncidentCustomFieldOption.
+type IncidentCustomFieldOptionData struct {
+	DataType string      `json:"data_type"`
+	Value    interface{} `json:"value"`
+}
+
+// IncidentCustomFieldValue is the value of a custom field on an incident.
+type IncidentCustomFieldValue struct {
+	ID          string `json:"id,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Name        string `json:"name,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+	Description string `json:"description,omitempty"`
+	DataType    string `json:"data_type,omitempty"`
+	FieldType   string `json:"field_type,omitempty"`
+
+	// Value is nil when the field has no value. Otherwise it's decoded as a
+	// string, a float64 for integer and float fields, or a bool, and multi
+	// value fields hold a []interface{} of those.
+	Value interface{} `json:"value"`
+}
+
+// ListIncidentCustomFieldsResponse is the data structure returned from calling
+// the ListIncidentCustomFields API endpoint.
+type ListIncidentCustomFieldsResponse struct {
+	Fields []IncidentCustomField `json:"fields"`
+}
+
+// ListIncidentCustomFields lists the custom fields defined for incidents.
+func (c *Client) ListIncidentCustomFields(ctx context.Context) (*ListIncidentCustomFieldsResponse, error) {
+	resp, err := c.get(ctx, "/incidents/custom_fields")
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListIncidentCustomFieldsResponse
+	return &result, c.decodeJSON(resp, &result)
+}
+
+// CreateIncidentCustomField creates a new custom field for incidents.
+func (c *Client) CreateIncidentCustomField(ctx context.Context, f IncidentCustomField) (*IncidentCustomField, error) {
+	resp, err := c.post(ctx, "/incidents/custom_fields", wrapBody("field", f), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var target map[string]IncidentCustomField
+	if dErr := c.decodeJSON(resp, &target); dErr != nil {
+		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
+	}
+
+	const rootNode = "field"
+
+	t, nodeOK := target[rootNode]
+	if !nodeOK {
+		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
+	}
+
+	return &t, nil
+}
+
+// GetIncidentCustomFieldValues gets the value of every custom field on an
+// incident.
+func (c *Client) GetIncidentCustomFieldValues(ctx context.Context, incidentID string) ([]IncidentCustomFieldValue, error) {
+	resp, err := c.get(ctx, "/incidents/"+incidentID+"/custom_fields/values")
+	return getIncidentCustomFieldValuesFromResponse(c, resp, err)
+}
+
+// UpdateIncidentCustomFieldValues sets the values of custom fields on an
+// incident, and returns the values of all of its custom fields. Each value
+// identifies its field by ID or Name; only the ID or Name and the Value are
+// sent, and a nil Value clears the field.
+func (c *Client) UpdateIncidentCustomFieldValues(ctx context.Context, incidentID string, values []IncidentCustomFieldValue) ([]IncidentCustomFieldValue, error) {
+	update := make([]IncidentCustomFieldValue, len(values))
+	for i, v := range values {
+		update[i] = IncidentCustomFieldValue{ID: v.ID, Name: v.Name, Value: v.Value}
+	}
+
+	resp, err := c.put(ctx, "/incidents/"+incidentID+"/custom_fields/values", wrapBody("custom_fields", update), nil)
+	return getIncidentCustomFieldValuesFromResponse(c, resp, err)
+}
+
+func getIncidentCustomFieldValuesFromResponse(c *Client, resp *http.Response, err error) ([]IncidentCustomFieldValue, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	var target map[string][]IncidentCustomFieldValue
+	if dErr := c.decodeJSON(resp, &target); dErr != nil {
+		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
+	}
+
+	const rootNode = "custom_fields"
+
+	t, nodeOK := target[rootNode]
+	if !nodeOK {
+		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
+	}
+
+	return t, nil
+}
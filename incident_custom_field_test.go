@@ -0,0 +1,119 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// List Incident Custom Fields
+func TestIncidentCustomField_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incidents/custom_fields", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Write([]byte(`{"fields": [{
+			"id": "PFLD1",
+			"name": "root_cause_category",
+			"display_name": "Root cause",
+			"data_type": "string",
+			"field_type": "single_value_fixed",
+			"field_options": [{"id": "POPT1", "data": {"data_type": "string", "value": "network"}}]
+		}]}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.ListIncidentCustomFields(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []IncidentCustomField{
+		{
+			ID:           "PFLD1",
+			Name:         "root_cause_category",
+			DisplayName:  "Root cause",
+			DataType:     IncidentCustomFieldDataTypeString,
+			FieldType:    IncidentCustomFieldTypeSingleValueFixed,
+			FieldOptions: []IncidentCustomFieldOption{{ID: "POPT1", Data: IncidentCustomFieldOptionData{DataType: "string", Value: "network"}}},
+		},
+	}
+	testEqual(t, want, res.Fields)
+}
+
+// Create Incident Custom Field
+func TestIncidentCustomField_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incidents/custom_fields", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body map[string]IncidentCustomField
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		testEqual(t, IncidentCustomFieldDataTypeInteger, body["field"].DataType)
+
+		w.Write([]byte(`{"field": {"id": "PFLD2", "name": "impacted_users", "data_type": "integer", "field_type": "single_value", "default_value": 0}}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.CreateIncidentCustomField(context.Background(), IncidentCustomField{
+		Name:      "impacted_users",
+		DataType:  IncidentCustomFieldDataTypeInteger,
+		FieldType: IncidentCustomFieldTypeSingleValue,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "PFLD2", res.ID)
+	testEqual(t, float64(0), res.DefaultValue)
+}
+
+// Get and Update Incident Custom Field Values
+func TestIncidentCustomField_Values(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incidents/PINC1/custom_fields/values", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			var body map[string][]map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			testEqual(t, []map[string]interface{}{{"name": "root_cause_category", "value": "network"}}, body["custom_fields"])
+		}
+
+		w.Write([]byte(`{"custom_fields": [
+			{"id": "PFLD1", "name": "root_cause_category", "data_type": "string", "field_type": "single_value_fixed", "value": "network"},
+			{"id": "PFLD3", "name": "regions", "data_type": "string", "field_type": "multi_value", "value": ["us", "eu"]},
+			{"id": "PFLD4", "name": "customer_facing", "data_type": "boolean", "field_type": "single_value", "value": null}
+		]}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.GetIncidentCustomFieldValues(context.Background(), "PINC1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "network", res[0].Value)
+	testEqual(t, []interface{}{"us", "eu"}, res[1].Value)
+	testEqual(t, nil, res[2].Value)
+
+	res, err = client.UpdateIncidentCustomFieldValues(context.Background(), "PINC1", []IncidentCustomFieldValue{
+		{Name: "root_cause_category", DisplayName: "Root cause", Value: "network"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, 3, len(res))
+}
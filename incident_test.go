@@ -1,8 +1,11 @@
 package pagerduty
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func TestIncident_List(t *testing.T) {
@@ -34,6 +37,69 @@ func TestIncident_List(t *testing.T) {
 	}
 	testEqual(t, want, res)
 }
+
+// List all Incidents
+func TestIncident_ListAll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incidents", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		q := r.URL.Query()
+		testEqual(t, "1", q.Get("limit"))
+		testEqual(t, []string{"triggered"}, q["statuses[]"])
+
+		switch offsets := q["offset"]; {
+		case len(offsets) != 1:
+			t.Errorf("offsets = %v, want a single offset", offsets)
+		case offsets[0] == "0":
+			w.Write([]byte(`{"incidents": [{"id": "1"}], "offset": 0, "limit": 1, "more": true}`))
+		default:
+			w.Write([]byte(`{"incidents": [{"id": "2"}], "offset": 1, "limit": 1, "more": false}`))
+		}
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	opts := ListIncidentsOptions{
+		APIListObject: APIListObject{Limit: 1, Offset: 20},
+		Statuses:      []string{"triggered"},
+		ListAll:       true,
+	}
+	res, err := client.ListIncidentsWithContext(context.Background(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &ListIncidentsResponse{Incidents: []Incident{{Id: "1"}, {Id: "2"}}}
+	testEqual(t, want, res)
+}
+
+// Get the open Incident count of a Service
+func TestIncident_GetServiceOpenIncidentCount(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incidents", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		q := r.URL.Query()
+		testEqual(t, "true", q.Get("total"))
+		testEqual(t, "1", q.Get("limit"))
+		testEqual(t, "all", q.Get("date_range"))
+		testEqual(t, []string{"triggered", "acknowledged"}, q["statuses[]"])
+		testEqual(t, []string{"PSVC1"}, q["service_ids[]"])
+		w.Write([]byte(`{"incidents": [{"id": "1"}], "limit": 1, "more": true, "total": 7}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.GetServiceOpenIncidentCount(context.Background(), "PSVC1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, 7, res)
+}
+
 func TestIncident_Create(t *testing.T) {
 	setup()
 	defer teardown()
@@ -64,6 +130,37 @@ func TestIncident_Create(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+func TestIncident_CreateWithContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	input := &CreateIncidentOptions{
+		Type:  "incident",
+		Title: "foo",
+	}
+
+	mux.HandleFunc("/incidents", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if got := r.Header.Get("From"); got != "foo@bar.com" {
+			t.Errorf("From header = %q, want %q", got, "foo@bar.com")
+		}
+		w.Write([]byte(`{"incident": {"title": "foo", "id": "1"}}`))
+	})
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.CreateIncidentWithContext(context.Background(), "foo@bar.com", input)
+
+	want := &Incident{
+		Title: "foo",
+		Id:    "1",
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, want, res)
+}
+
 func TestIncident_Manage_status(t *testing.T) {
 	setup()
 	defer teardown()
@@ -234,6 +331,35 @@ func TestIncident_Merge(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+// Merge Incidents by ID
+func TestIncident_MergeByID(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incidents/1/merge", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testEqual(t, "foo@bar.com", r.Header.Get("From"))
+
+		var body map[string][]MergeIncidentsOptions
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		testEqual(t, []MergeIncidentsOptions{{ID: "2", Type: "incident_reference"}, {ID: "3", Type: "incident_reference"}}, body["source_incidents"])
+
+		w.Write([]byte(`{"incident": {"title": "foo", "id": "1"}}`))
+	})
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.MergeIncidentsByID(context.Background(), "foo@bar.com", "1", []string{"2", "3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, &Incident{Id: "1", Title: "foo"}, res)
+
+	_, err = client.MergeIncidentsByID(context.Background(), "foo@bar.com", "1", nil)
+	testErrCheck(t, "MergeIncidentsByID()", "no source incidents to merge into 1", err)
+}
+
 func TestIncident_Get(t *testing.T) {
 	setup()
 	defer teardown()
@@ -400,6 +526,68 @@ func TestIncident_CreateIncidentNoteWithResponse(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+func TestIncident_CreateIncidentNoteWithContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incidents/1/notes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if got := r.Header.Get("From"); got != "foo@bar.com" {
+			t.Errorf("From header = %q, want %q", got, "foo@bar.com")
+		}
+		w.Write([]byte(`{"note": {"id": "1", "content": "foo", "created_at": "2020-10-01T10:00:00Z", "user": {"id": "U1", "type": "user_reference"}}}`))
+	})
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.CreateIncidentNoteWithContext(context.Background(), "foo@bar.com", "1", IncidentNote{Content: "foo"})
+
+	want := &IncidentNote{
+		ID:        "1",
+		Content:   "foo",
+		CreatedAt: "2020-10-01T10:00:00Z",
+		User:      APIObject{ID: "U1", Type: "user_reference"},
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, want, res)
+}
+
+func TestIncident_CreateIncidentStatusUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incidents/1/status_updates", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if got := r.Header.Get("From"); got != "foo@bar.com" {
+			t.Errorf("From header = %q, want %q", got, "foo@bar.com")
+		}
+
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		testEqual(t, map[string]string{"message": "rolling back"}, body)
+
+		w.Write([]byte(`{"status_update": {"id": "S1", "message": "rolling back", "sender": {"id": "U1", "type": "user_reference"}}}`))
+	})
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.CreateIncidentStatusUpdate(context.Background(), "foo@bar.com", "1", "rolling back")
+
+	want := &IncidentStatusUpdate{
+		ID:      "S1",
+		Message: "rolling back",
+		Sender:  APIObject{ID: "U1", Type: "user_reference"},
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, want, res)
+}
+
 // SnoozeIncident
 func TestIncident_SnoozeIncident(t *testing.T) {
 	setup()
@@ -419,6 +607,71 @@ func TestIncident_SnoozeIncident(t *testing.T) {
 	}
 }
 
+// SnoozeIncidentUntil
+func TestIncident_SnoozeIncidentUntil(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incidents/1/snooze", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if got := r.Header.Get("From"); got != "foo@bar.com" {
+			t.Errorf("From header = %q, want %q", got, "foo@bar.com")
+		}
+		var body map[string]uint
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if d := body["duration"]; d < 3590 || d > 3600 {
+			t.Errorf("duration = %d, want ~3600", d)
+		}
+		w.Write([]byte(`{"incident": {"id": "1"}}`))
+	})
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.SnoozeIncidentUntil(context.Background(), "1", "foo@bar.com", time.Now().Add(time.Hour))
+
+	want := &Incident{
+		Id: "1",
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, want, res)
+
+	_, err = client.SnoozeIncidentUntil(context.Background(), "1", "foo@bar.com", time.Now().Add(-time.Minute))
+	testErrCheck(t, "client.SnoozeIncidentUntil()", "not in the future", err)
+}
+
+// SnoozeIncident with context
+func TestIncident_SnoozeIncidentWithContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incidents/1/snooze", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testEqual(t, "foo@bar.com", r.Header.Get("From"))
+
+		var body map[string]uint
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		testEqual(t, uint(600), body["duration"])
+
+		w.Write([]byte(`{"incident": {"id": "1"}}`))
+	})
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.SnoozeIncidentWithContext(context.Background(), "foo@bar.com", "1", 600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, &Incident{Id: "1"}, res)
+
+	_, err = client.SnoozeIncidentWithContext(context.Background(), "foo@bar.com", "1", 0)
+	testErrCheck(t, "client.SnoozeIncidentWithContext()", "must be greater than zero", err)
+}
+
 // SnoozeIncidentWithResponse
 func TestIncident_SnoozeIncidentWithResponse(t *testing.T) {
 	setup()
@@ -532,6 +785,77 @@ func TestIncident_ListLogEntriesSinceUntil(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+// ListMergedIncidents
+func TestIncident_ListMergedIncidents(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incidents", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got := r.URL.Query().Get("statuses[]"); got != "resolved" {
+			t.Errorf("statuses[] = %q, want %q", got, "resolved")
+		}
+		w.Write([]byte(`{"incidents": [
+			{"id": "2", "resolve_reason": {"type": "merge_resolve_reason", "incident": {"id": "1"}}},
+			{"id": "3", "resolve_reason": {"type": "merge_resolve_reason", "incident": {"id": "9"}}},
+			{"id": "4"},
+			{"id": "5", "resolve_reason": {"type": "merge_resolve_reason", "incident": {"id": "1"}}}
+		], "more": false}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+	res, err := client.ListMergedIncidents(context.Background(), "1", ListIncidentsOptions{Statuses: []string{"triggered"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, i := range res {
+		got = append(got, i.Id)
+	}
+	testEqual(t, []string{"2", "5"}, got)
+}
+
+// ListPastIncidents
+func TestIncident_ListPastIncidents(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incidents/1/past_incidents", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got := r.URL.Query().Get("limit"); got != "2" {
+			t.Errorf("limit = %q, want %q", got, "2")
+		}
+		if got := r.URL.Query().Get("total"); got != "true" {
+			t.Errorf("total = %q, want %q", got, "true")
+		}
+		w.Write([]byte(`{"past_incidents": [{"incident": {"id": "2", "title": "foo"}, "score": 46.8}, {"incident": {"id": "3", "title": "bar"}, "score": 12.5}], "limit": 2, "total": 7}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+	res, err := client.ListPastIncidents(context.Background(), "1", 2, true)
+
+	want := &ListPastIncidentsResponse{
+		Limit: 2,
+		Total: 7,
+		PastIncidents: []PastIncident{
+			{
+				Incident: Incident{Id: "2", Title: "foo"},
+				Score:    46.8,
+			},
+			{
+				Incident: Incident{Id: "3", Title: "bar"},
+				Score:    12.5,
+			},
+		},
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, want, res)
+}
+
 func TestIncident_ResponderRequest(t *testing.T) {
 	setup()
 	defer teardown()
@@ -602,13 +926,68 @@ func TestIncident_ResponderRequest(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+// Create Incident Responder Request
+func TestIncident_CreateIncidentResponderRequest(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incidents/1/responder_requests", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testEqual(t, "foo@bar.com", r.Header.Get("From"))
+
+		var body struct {
+			RequesterID string                         `json:"requester_id"`
+			Targets     []map[string]map[string]string `json:"responder_request_targets"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		testEqual(t, "PL1JMK5", body.RequesterID)
+		testEqual(t, []map[string]map[string]string{
+			{"responder_request_target": {"id": "PEP1", "type": "escalation_policy_reference"}},
+		}, body.Targets)
+
+		w.Write([]byte(`{"responder_request": {
+			"incident": {"id": "1", "type": "incident_reference"},
+			"requester": {"id": "PL1JMK5", "type": "user_reference"},
+			"message": "Help",
+			"responder_request_targets": [{"responder_request_target": {
+				"id": "PEP1",
+				"type": "escalation_policy_reference",
+				"incident_responders": [{"state": "pending", "user": {"id": "PJ25ZYX"}}]
+			}}]
+		}}`))
+	})
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	o := ResponderRequestOptions{
+		Message:     "Help",
+		RequesterID: "PL1JMK5",
+		Targets:     []ResponderRequestTarget{{APIObject: APIObject{ID: "PEP1", Type: "escalation_policy_reference"}}},
+	}
+
+	res, err := client.CreateIncidentResponderRequest(context.Background(), "foo@bar.com", "1", o)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []IncidentResponderRequestTargets{
+		{Target: IncidentResponderRequestTarget{
+			APIObject:  APIObject{ID: "PEP1", Type: "escalation_policy_reference"},
+			Responders: []IncidentResponders{{State: "pending", User: APIObject{ID: "PJ25ZYX"}}},
+		}},
+	}
+	testEqual(t, want, res.Targets)
+	testEqual(t, "Help", res.Message)
+}
+
 func TestIncident_GetAlert(t *testing.T) {
 	setup()
 	defer teardown()
 
 	mux.HandleFunc("/incidents/1/alerts/1", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "GET")
-		w.Write([]byte(`{"alert": {"id": "1"}}`))
+		w.Write([]byte(`{"alert": {"id": "1", "body": {"type": "alert_body", "contexts": [{"type": "link", "href": "https://example.com/graph", "text": "Graph"}], "details": {"host": "db1"}}}}`))
 	})
 
 	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
@@ -622,6 +1001,11 @@ func TestIncident_GetAlert(t *testing.T) {
 			APIObject: APIObject{
 				ID: "1",
 			},
+			Body: &IncidentAlertBody{
+				Type:     "alert_body",
+				Contexts: []IncidentAlertContext{{Type: "link", Href: "https://example.com/graph", Text: "Graph"}},
+				Details:  map[string]interface{}{"host": "db1"},
+			},
 		},
 	}
 
@@ -669,3 +1053,49 @@ func TestIncident_ManageAlerts(t *testing.T) {
 	}
 	testEqual(t, want, res)
 }
+
+func TestIncident_ManageAlertsWithContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incidents/1/alerts/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testEqual(t, "foo@bar.com", r.Header.Get("From"))
+
+		var body IncidentAlertList
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if len(body.Alerts) != 1 || body.Alerts[0].Incident.ID != "2" {
+			t.Errorf("alerts = %+v, want alert moved to incident 2", body.Alerts)
+		}
+
+		w.Write([]byte(`{"alerts": [{"id": "A1", "incident": {"id": "2", "type": "incident_reference"}}]}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	input := &IncidentAlertList{
+		Alerts: []IncidentAlert{
+			{
+				APIObject: APIObject{ID: "A1", Type: "alert"},
+				Incident:  APIReference{ID: "2", Type: "incident_reference"},
+			},
+		},
+	}
+	res, _, err := client.ManageIncidentAlertsWithContext(context.Background(), "foo@bar.com", "1", input)
+
+	want := &ListAlertsResponse{
+		Alerts: []IncidentAlert{
+			{
+				APIObject: APIObject{ID: "A1"},
+				Incident:  APIReference{ID: "2", Type: "incident_reference"},
+			},
+		},
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, want, res)
+}
@@ -0,0 +1,103 @@
+package pagerduty
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/go-querystring/query"
+)
+
+// License is a license, or seat type, available to the account, such as Full
+// User or Stakeholder.
+type License struct {
+	ID          string `json:"id,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Summary     string `json:"summary,omitempty"`
+	RoleGroup   string `json:"role_group,omitempty"`
+
+	// ValidRoles are the user roles a user holding the license may have.
+	ValidRoles []string `json:"valid_roles,omitempty"`
+
+	// CurrentValue is the number of users the license is allocated to, and
+	// AllocationsAvailable how many more users it can be allocated to. Either
+	// is nil when the license has no limit.
+	CurrentValue         *int `json:"current_value"`
+	AllocationsAvailable *int `json:"allocations_available"`
+
+	HTMLURL string `json:"html_url,omitempty"`
+	Self    string `json:"self,omitempty"`
+}
+
+// LicenseAllocation is the license allocated to a user.
+type LicenseAllocation struct {
+	AllocatedAt string    `json:"allocated_at,omitempty"`
+	User        APIObject `json:"user"`
+	License     License   `json:"license"`
+}
+
+// ListLicensesResponse is the data structure returned from calling the
+// ListLicenses API endpoint.
+type ListLicensesResponse struct {
+	Licenses []License `json:"licenses"`
+}
+
+// ListLicenseAllocationsOptions is the data structure used when calling the
+// ListLicenseAllocations API endpoint.
+type ListLicenseAllocationsOptions struct {
+	// Limit is the number of allocations to ask for per page.
+	Limit uint `url:"limit,omitempty"`
+}
+
+// ListLicenseAllocationsResponse is the data structure returned from calling
+// the ListLicenseAllocations API endpoint.
+type ListLicenseAllocationsResponse struct {
+	APIListObject
+	LicenseAllocations []LicenseAllocation `json:"license_allocations"`
+}
+
+// ListLicenses lists the licenses available to the account and how many of
+// each are in use.
+func (c *Client) ListLicenses(ctx context.Context) (*ListLicensesResponse, error) {
+	resp, err := c.get(ctx, "/licenses")
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListLicensesResponse
+	return &result, c.decodeJSON(resp, &result)
+}
+
+// ListLicenseAllocations lists the license allocated to each user of the
+// account, following every page of results. If a page fails the allocations
+// of the pages before it are returned along with a PageError.
+func (c *Client) ListLicenseAllocations(ctx context.Context, o ListLicenseAllocationsOptions) ([]LicenseAllocation, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	allocations := make([]LicenseAllocation, 0)
+
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		var result ListLicenseAllocationsResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return APIListObject{}, err
+		}
+
+		allocations = append(allocations, result.LicenseAllocations...)
+
+		return APIListObject{
+			More:   result.More,
+			Offset: result.Offset,
+			Limit:  result.Limit,
+		}, nil
+	}
+
+	if err := c.pagedGet(ctx, "/license_allocations?"+v.Encode(), responseHandler); err != nil {
+		return allocations, err
+	}
+
+	return allocations, nil
+}
@@ -0,0 +1,68 @@
+package pagerduty
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+// List Licenses
+func TestLicense_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/licenses", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Write([]byte(`{"licenses": [
+			{"id": "PLIC1", "name": "Full User", "current_value": 5, "allocations_available": 20},
+			{"id": "PLIC2", "name": "Stakeholder", "current_value": null, "allocations_available": null}
+		]}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.ListLicenses(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	current, available := 5, 20
+	want := []License{
+		{ID: "PLIC1", Name: "Full User", CurrentValue: &current, AllocationsAvailable: &available},
+		{ID: "PLIC2", Name: "Stakeholder"},
+	}
+	testEqual(t, want, res.Licenses)
+}
+
+// List License Allocations
+func TestLicense_ListAllocations(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/license_allocations", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "1", r.URL.Query().Get("limit"))
+
+		switch r.URL.Query().Get("offset") {
+		case "0":
+			w.Write([]byte(`{"license_allocations": [{"allocated_at": "2021-06-01T00:00:00Z", "user": {"id": "PUSER1"}, "license": {"id": "PLIC1"}}], "offset": 0, "limit": 1, "more": true}`))
+		case "1":
+			w.Write([]byte(`{"license_allocations": [{"allocated_at": "2021-06-02T00:00:00Z", "user": {"id": "PUSER2"}, "license": {"id": "PLIC2"}}], "offset": 1, "limit": 1, "more": false}`))
+		default:
+			t.Errorf("unexpected offset %q", r.URL.Query().Get("offset"))
+		}
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.ListLicenseAllocations(context.Background(), ListLicenseAllocationsOptions{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []LicenseAllocation{
+		{AllocatedAt: "2021-06-01T00:00:00Z", User: APIObject{ID: "PUSER1"}, License: License{ID: "PLIC1"}},
+		{AllocatedAt: "2021-06-02T00:00:00Z", User: APIObject{ID: "PUSER2"}, License: License{ID: "PLIC2"}},
+	}
+	testEqual(t, want, res)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 
 	"github.com/google/go-querystring/query"
 )
@@ -50,6 +51,15 @@ type ListLogEntryResponse struct {
 	LogEntries []LogEntry `json:"log_entries"`
 }
 
+// The values accepted by the Includes field of ListLogEntriesOptions,
+// GetLogEntryOptions and ListIncidentLogEntriesOptions.
+const (
+	LogEntryIncludeChannels  = "channels"
+	LogEntryIncludeIncidents = "incidents"
+	LogEntryIncludeServices  = "services"
+	LogEntryIncludeTeams     = "teams"
+)
+
 // ListLogEntriesOptions is the data structure used when calling the ListLogEntry API endpoint.
 type ListLogEntriesOptions struct {
 	APIListObject
@@ -58,15 +68,41 @@ type ListLogEntriesOptions struct {
 	Until      string   `url:"until,omitempty"`
 	IsOverview bool     `url:"is_overview,omitempty"`
 	Includes   []string `url:"include,omitempty,brackets"`
+
+	// ListAll makes ListLogEntries return every log entry in a single response,
+	// with Limit as the page size. Offset is ignored, and ListAll isn't sent to
+	// the API.
+	ListAll bool `url:"-"`
 }
 
 // ListLogEntries lists all of the incident log entries across the entire account.
 func (c *Client) ListLogEntries(o ListLogEntriesOptions) (*ListLogEntryResponse, error) {
+	return c.ListLogEntriesWithContext(context.TODO(), o)
+}
+
+// ListLogEntriesWithContext lists all of the incident log entries across the entire account.
+func (c *Client) ListLogEntriesWithContext(ctx context.Context, o ListLogEntriesOptions) (*ListLogEntryResponse, error) {
+	if o.ListAll {
+		var all ListLogEntryResponse
+		responseHandler := func(response *http.Response) (APIListObject, error) {
+			var result ListLogEntryResponse
+			if err := c.decodeJSON(response, &result); err != nil {
+				return APIListObject{}, err
+			}
+			all.LogEntries = append(all.LogEntries, result.LogEntries...)
+			return result.APIListObject, nil
+		}
+		if err := c.pagedGetAll(ctx, "/log_entries", o, responseHandler); err != nil {
+			return nil, err
+		}
+		return &all, nil
+	}
+
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.get(context.TODO(), "/log_entries?"+v.Encode())
+	resp, err := c.get(ctx, "/log_entries?"+v.Encode())
 	if err != nil {
 		return nil, err
 	}
@@ -85,11 +121,16 @@ type GetLogEntryOptions struct {
 
 // GetLogEntry list log entries for the specified incident.
 func (c *Client) GetLogEntry(id string, o GetLogEntryOptions) (*LogEntry, error) {
+	return c.GetLogEntryWithContext(context.TODO(), id, o)
+}
+
+// GetLogEntryWithContext list log entries for the specified incident.
+func (c *Client) GetLogEntryWithContext(ctx context.Context, id string, o GetLogEntryOptions) (*LogEntry, error) {
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.get(context.TODO(), "/log_entries/"+id+"?"+v.Encode())
+	resp, err := c.get(ctx, "/log_entries/"+id+"?"+v.Encode())
 	if err != nil {
 		return nil, err
 	}
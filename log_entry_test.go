@@ -1,6 +1,7 @@
 package pagerduty
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"testing"
@@ -45,6 +46,46 @@ func TestLogEntry_List(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+func TestLogEntry_ListWithContextFilters(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/log_entries", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		q := r.URL.Query()
+		testEqual(t, "true", q.Get("is_overview"))
+		testEqual(t, "2025-01-01T00:00:00Z", q.Get("since"))
+		testEqual(t, "2025-01-02T00:00:00Z", q.Get("until"))
+		testEqual(t, []string{LogEntryIncludeChannels, LogEntryIncludeIncidents}, q["include[]"])
+		w.Write([]byte(`{"log_entries": [{"id": "1", "type": "trigger_log_entry", "created_at": "2025-01-01T01:00:00Z", "agent": {"id": "PSVC1", "type": "service_reference"}, "channel": {"type": "api"}, "event_details": {"description": "disk full"}}]}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+	opts := ListLogEntriesOptions{
+		Since:      "2025-01-01T00:00:00Z",
+		Until:      "2025-01-02T00:00:00Z",
+		IsOverview: true,
+		Includes:   []string{LogEntryIncludeChannels, LogEntryIncludeIncidents},
+	}
+	res, err := client.ListLogEntriesWithContext(context.Background(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []LogEntry{
+		{
+			CommonLogEntryField: CommonLogEntryField{
+				APIObject:    APIObject{ID: "1", Type: "trigger_log_entry"},
+				CreatedAt:    "2025-01-01T01:00:00Z",
+				Agent:        Agent{ID: "PSVC1", Type: "service_reference"},
+				Channel:      Channel{Type: "api", Raw: map[string]interface{}{"type": "api"}},
+				EventDetails: map[string]string{"description": "disk full"},
+			},
+		},
+	}
+	testEqual(t, want, res.LogEntries)
+}
+
 func TestLogEntry_Get(t *testing.T) {
 	setup()
 	defer teardown()
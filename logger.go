@@ -0,0 +1,35 @@
+package pagerduty
+
+// Logger is the interface used by the client to log what it's doing. It's
+// satisfied by most structured and leveled loggers, either directly or with a
+// small adapter.
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// noopLogger is the Logger used when the client has not been configured with
+// one. It discards everything.
+type noopLogger struct{}
+
+func (noopLogger) Debugf(string, ...interface{}) {}
+func (noopLogger) Infof(string, ...interface{})  {}
+func (noopLogger) Errorf(string, ...interface{}) {}
+
+// WithLogger configures the client to log through l. By default the client
+// does not log anything.
+func WithLogger(l Logger) ClientOptions {
+	return func(c *Client) {
+		c.logger = l
+	}
+}
+
+// log returns the Logger the client should use, falling back to a no-op
+// logger so that zero-value clients are safe to use.
+func (c *Client) log() Logger {
+	if c.logger == nil {
+		return noopLogger{}
+	}
+	return c.logger
+}
@@ -0,0 +1,58 @@
+package pagerduty
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type recordingLogger struct {
+	errors []string
+}
+
+func (l *recordingLogger) Debugf(string, ...interface{}) {}
+func (l *recordingLogger) Infof(string, ...interface{})  {}
+
+func (l *recordingLogger) Errorf(format string, args ...interface{}) {
+	l.errors = append(l.errors, fmt.Sprintf(format, args...))
+}
+
+func TestWithLogger(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	l := &recordingLogger{}
+	client := NewClient("foo", WithAPIEndpoint(server.URL), WithLogger(l))
+
+	if _, err := client.GetService("1", &GetServiceOptions{}); err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+
+	if len(l.errors) != 1 {
+		t.Fatalf("logged %d errors, want 1", len(l.errors))
+	}
+
+	if !strings.Contains(l.errors[0], "GET /services/1") {
+		t.Errorf("logged %q, want it to mention the request", l.errors[0])
+	}
+}
+
+func TestClient_nilLogger(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	if _, err := client.GetService("1", &GetServiceOptions{}); err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -11,13 +12,13 @@ import (
 // MaintenanceWindow is used to temporarily disable one or more services for a set period of time.
 type MaintenanceWindow struct {
 	APIObject
-	SequenceNumber uint            `json:"sequence_number,omitempty"`
-	StartTime      string          `json:"start_time"`
-	EndTime        string          `json:"end_time"`
-	Description    string          `json:"description"`
-	Services       []APIObject     `json:"services"`
-	Teams          []APIListObject `json:"teams"`
-	CreatedBy      APIListObject   `json:"created_by"`
+	SequenceNumber uint        `json:"sequence_number,omitempty"`
+	StartTime      string      `json:"start_time,omitempty"`
+	EndTime        string      `json:"end_time,omitempty"`
+	Description    string      `json:"description,omitempty"`
+	Services       []APIObject `json:"services,omitempty"`
+	Teams          []APIObject `json:"teams,omitempty"`
+	CreatedBy      *APIObject  `json:"created_by,omitempty"`
 }
 
 // ListMaintenanceWindowsResponse is the data structur returned from calling the ListMaintenanceWindows API endpoint.
@@ -26,6 +27,15 @@ type ListMaintenanceWindowsResponse struct {
 	MaintenanceWindows []MaintenanceWindow `json:"maintenance_windows"`
 }
 
+// The values accepted by the Filter field of ListMaintenanceWindowsOptions.
+const (
+	MaintenanceWindowFilterPast    = "past"
+	MaintenanceWindowFilterFuture  = "future"
+	MaintenanceWindowFilterOngoing = "ongoing"
+	MaintenanceWindowFilterOpen    = "open"
+	MaintenanceWindowFilterAll     = "all"
+)
+
 // ListMaintenanceWindowsOptions is the data structure used when calling the ListMaintenanceWindows API endpoint.
 type ListMaintenanceWindowsOptions struct {
 	APIListObject
@@ -34,15 +44,41 @@ type ListMaintenanceWindowsOptions struct {
 	TeamIDs    []string `url:"team_ids,omitempty,brackets"`
 	ServiceIDs []string `url:"service_ids,omitempty,brackets"`
 	Filter     string   `url:"filter,omitempty,brackets"`
+
+	// ListAll makes ListMaintenanceWindows return every maintenance window in a
+	// single response, with Limit as the page size. Offset is ignored, and ListAll
+	// isn't sent to the API.
+	ListAll bool `url:"-"`
 }
 
 // ListMaintenanceWindows lists existing maintenance windows, optionally filtered by service and/or team, or whether they are from the past, present or future.
 func (c *Client) ListMaintenanceWindows(o ListMaintenanceWindowsOptions) (*ListMaintenanceWindowsResponse, error) {
+	return c.ListMaintenanceWindowsWithContext(context.TODO(), o)
+}
+
+// ListMaintenanceWindowsWithContext lists existing maintenance windows, optionally filtered by service and/or team, or whether they are from the past, present or future.
+func (c *Client) ListMaintenanceWindowsWithContext(ctx context.Context, o ListMaintenanceWindowsOptions) (*ListMaintenanceWindowsResponse, error) {
+	if o.ListAll {
+		var all ListMaintenanceWindowsResponse
+		responseHandler := func(response *http.Response) (APIListObject, error) {
+			var result ListMaintenanceWindowsResponse
+			if err := c.decodeJSON(response, &result); err != nil {
+				return APIListObject{}, err
+			}
+			all.MaintenanceWindows = append(all.MaintenanceWindows, result.MaintenanceWindows...)
+			return result.APIListObject, nil
+		}
+		if err := c.pagedGetAll(ctx, "/maintenance_windows", o, responseHandler); err != nil {
+			return nil, err
+		}
+		return &all, nil
+	}
+
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.get(context.TODO(), "/maintenance_windows?"+v.Encode())
+	resp, err := c.get(ctx, "/maintenance_windows?"+v.Encode())
 	if err != nil {
 		return nil, err
 	}
@@ -52,17 +88,62 @@ func (c *Client) ListMaintenanceWindows(o ListMaintenanceWindowsOptions) (*ListM
 
 // CreateMaintenanceWindow creates a new maintenance window for the specified services.
 func (c *Client) CreateMaintenanceWindow(from string, o MaintenanceWindow) (*MaintenanceWindow, error) {
-	data := make(map[string]MaintenanceWindow)
+	return c.CreateMaintenanceWindowWithContext(context.TODO(), from, o)
+}
+
+// CreateMaintenanceWindowWithContext creates a new maintenance window for the specified services.
+func (c *Client) CreateMaintenanceWindowWithContext(ctx context.Context, from string, o MaintenanceWindow) (*MaintenanceWindow, error) {
 	o.Type = "maintenance_window"
-	data["maintenance_window"] = o
+
+	from, err := c.resolveFrom(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+
 	headers := make(map[string]string)
 	if from != "" {
 		headers["From"] = from
 	}
-	resp, err := c.post(context.TODO(), "/maintenance_windows", data, headers)
+	resp, err := c.post(ctx, "/maintenance_windows", wrapBody("maintenance_window", o), headers)
 	return getMaintenanceWindowFromResponse(c, resp, err)
 }
 
+// maintenanceWindowStartGrace is how far in the past the start of a new
+// maintenance window may be, so that windows meant to start right away aren't
+// rejected because a little time passed after the caller called time.Now.
+const maintenanceWindowStartGrace = time.Minute
+
+// CreateMaintenanceWindowForService creates a maintenance window covering a
+// single service from start until end. The start may not be in the past,
+// except by up to a minute so that a window can be started immediately, and
+// end must be in the future and after start.
+func (c *Client) CreateMaintenanceWindowForService(ctx context.Context, serviceID string, start, end time.Time, description string) (*MaintenanceWindow, error) {
+	now := time.Now()
+
+	if start.Before(now.Add(-maintenanceWindowStartGrace)) {
+		return nil, fmt.Errorf("maintenance window start time %s is in the past", start.Format(time.RFC3339))
+	}
+
+	if !end.After(now) {
+		return nil, fmt.Errorf("maintenance window end time %s is in the past", end.Format(time.RFC3339))
+	}
+
+	if !end.After(start) {
+		return nil, fmt.Errorf("maintenance window end time %s must be after start time %s", end.Format(time.RFC3339), start.Format(time.RFC3339))
+	}
+
+	m := MaintenanceWindow{
+		StartTime:   start.Format(time.RFC3339),
+		EndTime:     end.Format(time.RFC3339),
+		Description: description,
+		Services: []APIObject{
+			{ID: serviceID, Type: "service_reference"},
+		},
+	}
+
+	return c.CreateMaintenanceWindowWithContext(ctx, "", m)
+}
+
 // CreateMaintenanceWindows creates a new maintenance window for the specified services.
 // Deprecated: Use `CreateMaintenanceWindow` instead.
 func (c *Client) CreateMaintenanceWindows(o MaintenanceWindow) (*MaintenanceWindow, error) {
@@ -71,7 +152,12 @@ func (c *Client) CreateMaintenanceWindows(o MaintenanceWindow) (*MaintenanceWind
 
 // DeleteMaintenanceWindow deletes an existing maintenance window if it's in the future, or ends it if it's currently on-going.
 func (c *Client) DeleteMaintenanceWindow(id string) error {
-	_, err := c.delete(context.TODO(), "/maintenance_windows/"+id)
+	return c.DeleteMaintenanceWindowWithContext(context.TODO(), id)
+}
+
+// DeleteMaintenanceWindowWithContext deletes an existing maintenance window if it's in the future, or ends it if it's currently on-going.
+func (c *Client) DeleteMaintenanceWindowWithContext(ctx context.Context, id string) error {
+	_, err := c.delete(ctx, "/maintenance_windows/"+id)
 	return err
 }
 
@@ -82,20 +168,51 @@ type GetMaintenanceWindowOptions struct {
 
 // GetMaintenanceWindow gets an existing maintenance window.
 func (c *Client) GetMaintenanceWindow(id string, o GetMaintenanceWindowOptions) (*MaintenanceWindow, error) {
+	return c.GetMaintenanceWindowWithContext(context.TODO(), id, o)
+}
+
+// GetMaintenanceWindowWithContext gets an existing maintenance window.
+func (c *Client) GetMaintenanceWindowWithContext(ctx context.Context, id string, o GetMaintenanceWindowOptions) (*MaintenanceWindow, error) {
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.get(context.TODO(), "/maintenance_windows/"+id+"?"+v.Encode())
+	resp, err := c.get(ctx, "/maintenance_windows/"+id+"?"+v.Encode())
 	return getMaintenanceWindowFromResponse(c, resp, err)
 }
 
-// UpdateMaintenanceWindow updates an existing maintenance window.
+// UpdateMaintenanceWindow updates an existing maintenance window. Only the
+// fields set on m are sent, so leaving Services or Teams empty keeps the
+// window's existing associations rather than clearing them.
 func (c *Client) UpdateMaintenanceWindow(m MaintenanceWindow) (*MaintenanceWindow, error) {
-	resp, err := c.put(context.TODO(), "/maintenance_windows/"+m.ID, m, nil)
+	return c.UpdateMaintenanceWindowWithContext(context.TODO(), m)
+}
+
+// UpdateMaintenanceWindowWithContext updates an existing maintenance window.
+// Only the fields set on m are sent, so leaving Services or Teams empty keeps
+// the window's existing associations rather than clearing them.
+func (c *Client) UpdateMaintenanceWindowWithContext(ctx context.Context, m MaintenanceWindow) (*MaintenanceWindow, error) {
+	m.Type = "maintenance_window"
+	resp, err := c.put(ctx, "/maintenance_windows/"+m.ID, wrapBody("maintenance_window", m), nil)
 	return getMaintenanceWindowFromResponse(c, resp, err)
 }
 
+// UpdateMaintenanceWindowTimes changes when an existing maintenance window
+// starts and ends, leaving its description, services, and teams untouched.
+func (c *Client) UpdateMaintenanceWindowTimes(ctx context.Context, id string, start, end time.Time) (*MaintenanceWindow, error) {
+	if !end.After(start) {
+		return nil, fmt.Errorf("maintenance window end time %s must be after start time %s", end.Format(time.RFC3339), start.Format(time.RFC3339))
+	}
+
+	m := MaintenanceWindow{
+		APIObject: APIObject{ID: id},
+		StartTime: start.Format(time.RFC3339),
+		EndTime:   end.Format(time.RFC3339),
+	}
+
+	return c.UpdateMaintenanceWindowWithContext(ctx, m)
+}
+
 func getMaintenanceWindowFromResponse(c *Client, resp *http.Response, err error) (*MaintenanceWindow, error) {
 	if err != nil {
 		return nil, err
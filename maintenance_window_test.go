@@ -1,8 +1,11 @@
 package pagerduty
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 )
 
 // ListMaintenanceWindows
@@ -45,6 +48,40 @@ func TestMaintenanceWindow_List(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+// ListMaintenanceWindows with filters
+func TestMaintenanceWindow_ListWithContextFilters(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/maintenance_windows", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		q := r.URL.Query()
+		testEqual(t, MaintenanceWindowFilterFuture, q.Get("filter"))
+		testEqual(t, []string{"PSVC1", "PSVC2"}, q["service_ids[]"])
+		testEqual(t, []string{"PTEAM1"}, q["team_ids[]"])
+		w.Write([]byte(`{"maintenance_windows": [{"id": "1", "start_time": "2025-03-01T10:00:00Z"}]}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+	opts := ListMaintenanceWindowsOptions{
+		ServiceIDs: []string{"PSVC1", "PSVC2"},
+		TeamIDs:    []string{"PTEAM1"},
+		Filter:     MaintenanceWindowFilterFuture,
+	}
+	res, err := client.ListMaintenanceWindowsWithContext(context.Background(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []MaintenanceWindow{
+		{
+			APIObject: APIObject{ID: "1"},
+			StartTime: "2025-03-01T10:00:00Z",
+		},
+	}
+	testEqual(t, want, res.MaintenanceWindows)
+}
+
 // CreateMaintenanceWindow
 func TestMaintenanceWindow_Create(t *testing.T) {
 	setup()
@@ -104,6 +141,61 @@ func TestMaintenanceWindow_Create_NoFrom(t *testing.T) {
 }
 
 // DeleteMaintenanceWindows
+func TestMaintenanceWindow_CreateForService(t *testing.T) {
+	setup()
+	defer teardown()
+
+	start := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	end := start.Add(30 * time.Minute)
+
+	mux.HandleFunc("/maintenance_windows", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body map[string]MaintenanceWindow
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+
+		want := MaintenanceWindow{
+			APIObject:   APIObject{Type: "maintenance_window"},
+			StartTime:   start.Format(time.RFC3339),
+			EndTime:     end.Format(time.RFC3339),
+			Description: "deploy",
+			Services:    []APIObject{{ID: "S1", Type: "service_reference"}},
+		}
+		testEqual(t, want, body["maintenance_window"])
+
+		w.Write([]byte(`{"maintenance_window": {"id": "1", "description": "deploy"}}`))
+	})
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.CreateMaintenanceWindowForService(context.Background(), "S1", start, end, "deploy")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &MaintenanceWindow{
+		APIObject: APIObject{
+			ID: "1",
+		},
+		Description: "deploy",
+	}
+	testEqual(t, want, res)
+
+	_, err = client.CreateMaintenanceWindowForService(context.Background(), "S1", end, start, "deploy")
+	testErrCheck(t, "CreateMaintenanceWindowForService()", "must be after start time", err)
+
+	past := time.Now().Add(-time.Hour)
+	_, err = client.CreateMaintenanceWindowForService(context.Background(), "S1", past, end, "deploy")
+	testErrCheck(t, "CreateMaintenanceWindowForService()", "start time", err)
+
+	// within the start grace period, but already over
+	now := time.Now()
+	_, err = client.CreateMaintenanceWindowForService(context.Background(), "S1", now.Add(-50*time.Second), now.Add(-10*time.Second), "deploy")
+	testErrCheck(t, "CreateMaintenanceWindowForService()", "end time", err)
+	testErrCheck(t, "CreateMaintenanceWindowForService()", "is in the past", err)
+}
+
 func TestMaintenanceWindow_Delete(t *testing.T) {
 	setup()
 	defer teardown()
@@ -180,3 +272,98 @@ func TestMaintenanceWindow_Update(t *testing.T) {
 	}
 	testEqual(t, want, res)
 }
+
+// UpdateMaintenanceWindow only sends the fields that are set
+func TestMaintenanceWindow_UpdateSetFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	input := MaintenanceWindow{
+		APIObject:   APIObject{ID: "1"},
+		Description: "foo",
+		Teams:       []APIObject{{ID: "T1", Type: "team_reference"}},
+	}
+
+	mux.HandleFunc("/maintenance_windows/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var body map[string]map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+
+		want := map[string]interface{}{
+			"id":          "1",
+			"type":        "maintenance_window",
+			"description": "foo",
+			"teams":       []interface{}{map[string]interface{}{"id": "T1", "type": "team_reference"}},
+		}
+		testEqual(t, want, body["maintenance_window"])
+
+		w.Write([]byte(`{"maintenance_window": {"id": "1", "description": "foo", "teams": [{"id": "T1", "type": "team_reference"}], "created_by": {"id": "U1", "type": "user_reference"}}}`))
+	})
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.UpdateMaintenanceWindowWithContext(context.Background(), input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &MaintenanceWindow{
+		APIObject:   APIObject{ID: "1"},
+		Description: "foo",
+		Teams:       []APIObject{{ID: "T1", Type: "team_reference"}},
+		CreatedBy:   &APIObject{ID: "U1", Type: "user_reference"},
+	}
+	testEqual(t, want, res)
+}
+
+// UpdateMaintenanceWindowTimes
+func TestMaintenanceWindow_UpdateTimes(t *testing.T) {
+	setup()
+	defer teardown()
+
+	start := time.Date(2020, 10, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+
+	mux.HandleFunc("/maintenance_windows/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var body map[string]map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+
+		mw := body["maintenance_window"]
+		if mw["start_time"] != "2020-10-01T10:00:00Z" || mw["end_time"] != "2020-10-01T12:00:00Z" {
+			t.Errorf("times = %v - %v, want 2020-10-01T10:00:00Z - 2020-10-01T12:00:00Z", mw["start_time"], mw["end_time"])
+		}
+		for _, field := range []string{"services", "teams", "description"} {
+			if _, ok := mw[field]; ok {
+				t.Errorf("request body includes %q, which would overwrite it", field)
+			}
+		}
+
+		w.Write([]byte(`{"maintenance_window": {"id": "1", "start_time": "2020-10-01T10:00:00Z", "end_time": "2020-10-01T12:00:00Z", "services": [{"id": "S1"}]}}`))
+	})
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.UpdateMaintenanceWindowTimes(context.Background(), "1", start, end)
+
+	want := &MaintenanceWindow{
+		APIObject: APIObject{
+			ID: "1",
+		},
+		StartTime: "2020-10-01T10:00:00Z",
+		EndTime:   "2020-10-01T12:00:00Z",
+		Services:  []APIObject{{ID: "S1"}},
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, want, res)
+
+	_, err = client.UpdateMaintenanceWindowTimes(context.Background(), "1", end, start)
+	testErrCheck(t, "UpdateMaintenanceWindowTimes()", "must be after start time", err)
+}
@@ -2,6 +2,7 @@ package pagerduty
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/google/go-querystring/query"
 )
@@ -23,6 +24,11 @@ type ListNotificationOptions struct {
 	Until    string   `url:"until,omitempty"`
 	Filter   string   `url:"filter,omitempty"`
 	Includes []string `url:"include,omitempty"`
+
+	// ListAll makes ListNotifications return every notification in a single
+	// response, with Limit as the page size. Offset is ignored, and ListAll isn't
+	// sent to the API.
+	ListAll bool `url:"-"`
 }
 
 // ListNotificationsResponse is the data structure returned from the ListNotifications API endpoint.
@@ -33,6 +39,22 @@ type ListNotificationsResponse struct {
 
 // ListNotifications lists notifications for a given time range, optionally filtered by type (sms_notification, email_notification, phone_notification, or push_notification).
 func (c *Client) ListNotifications(o ListNotificationOptions) (*ListNotificationsResponse, error) {
+	if o.ListAll {
+		var all ListNotificationsResponse
+		responseHandler := func(response *http.Response) (APIListObject, error) {
+			var result ListNotificationsResponse
+			if err := c.decodeJSON(response, &result); err != nil {
+				return APIListObject{}, err
+			}
+			all.Notifications = append(all.Notifications, result.Notifications...)
+			return result.APIListObject, nil
+		}
+		if err := c.pagedGetAll(context.TODO(), "/notifications", o, responseHandler); err != nil {
+			return nil, err
+		}
+		return &all, nil
+	}
+
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
@@ -2,6 +2,7 @@ package pagerduty
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/google/go-querystring/query"
 )
@@ -33,18 +34,70 @@ type ListOnCallOptions struct {
 	Since               string   `url:"since,omitempty"`
 	Until               string   `url:"until,omitempty"`
 	Earliest            bool     `url:"earliest,omitempty"`
+
+	// ListAll makes ListOnCalls return every on-call entry in a single
+	// response, like ListOnCallsPaginated. Limit is then the page size and
+	// Offset is ignored. It isn't sent to the API.
+	ListAll bool `url:"-"`
 }
 
 // ListOnCalls list the on-call entries during a given time range.
 func (c *Client) ListOnCalls(o ListOnCallOptions) (*ListOnCallsResponse, error) {
+	return c.ListOnCallsWithContext(context.TODO(), o)
+}
+
+// ListOnCallsWithContext list the on-call entries during a given time range.
+func (c *Client) ListOnCallsWithContext(ctx context.Context, o ListOnCallOptions) (*ListOnCallsResponse, error) {
+	if o.ListAll {
+		oncalls, err := c.ListOnCallsPaginated(ctx, o)
+		if err != nil {
+			return nil, err
+		}
+		return &ListOnCallsResponse{OnCalls: oncalls}, nil
+	}
+
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.get(context.TODO(), "/oncalls?"+v.Encode())
+	resp, err := c.get(ctx, "/oncalls?"+v.Encode())
 	if err != nil {
 		return nil, err
 	}
 	var result ListOnCallsResponse
 	return &result, c.decodeJSON(resp, &result)
 }
+
+// ListOnCallsPaginated lists all of the on-call entries matching o, following
+// pagination. o.Offset is ignored.
+func (c *Client) ListOnCallsPaginated(ctx context.Context, o ListOnCallOptions) ([]OnCall, error) {
+	o.APIListObject = APIListObject{Limit: o.Limit}
+
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	oncalls := make([]OnCall, 0)
+
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		var result ListOnCallsResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return APIListObject{}, err
+		}
+
+		oncalls = append(oncalls, result.OnCalls...)
+
+		return APIListObject{
+			More:   result.More,
+			Offset: result.Offset,
+			Limit:  result.Limit,
+		}, nil
+	}
+
+	if err := c.pagedGet(ctx, "/oncalls?"+v.Encode(), responseHandler); err != nil {
+		return nil, err
+	}
+
+	return oncalls, nil
+}
@@ -1,6 +1,7 @@
 package pagerduty
 
 import (
+	"context"
 	"net/http"
 	"testing"
 )
@@ -44,3 +45,36 @@ func TestOnCall_List(t *testing.T) {
 	}
 	testEqual(t, want, res)
 }
+
+// ListOnCallsPaginated
+func TestOnCall_ListPaginated(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/oncalls", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "true", r.URL.Query().Get("earliest"))
+
+		switch offset := r.URL.Query().Get("offset"); offset {
+		case "0":
+			w.Write([]byte(`{"oncalls": [{"escalation_level": 1}], "limit": 1, "offset": 0, "more": true}`))
+		case "1":
+			w.Write([]byte(`{"oncalls": [{"escalation_level": 2}], "limit": 1, "offset": 1, "more": false}`))
+		default:
+			t.Errorf("unexpected offset %q", offset)
+		}
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.ListOnCallsPaginated(context.Background(), ListOnCallOptions{Earliest: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []OnCall{
+		{EscalationLevel: 1},
+		{EscalationLevel: 2},
+	}
+	testEqual(t, want, res)
+}
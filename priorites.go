@@ -2,7 +2,9 @@ package pagerduty
 
 import (
 	"context"
-	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
 )
 
 // PriorityProperty is a single priorty object returned from the Priorities endpoint
@@ -10,8 +12,10 @@ type PriorityProperty struct {
 	APIObject
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	Color       string `json:"color,omitempty"`
 }
 
+// Priorities is the data structure returned from calling the ListPriorities API endpoint.
 type Priorities struct {
 	APIListObject
 	Priorities []PriorityProperty `json:"priorities"`
@@ -19,18 +23,100 @@ type Priorities struct {
 
 // ListPriorities lists existing priorities
 func (c *Client) ListPriorities() (*Priorities, error) {
-	resp, err := c.get(context.TODO(), "/priorities")
+	return c.ListPrioritiesWithContext(context.TODO())
+}
+
+// ListPrioritiesWithContext lists existing priorities
+func (c *Client) ListPrioritiesWithContext(ctx context.Context) (*Priorities, error) {
+	resp, err := c.get(ctx, "/priorities")
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO(theckman): make sure we close the resp.Body here
-
 	var p Priorities
-	err = json.NewDecoder(resp.Body).Decode(&p)
+	if err := c.decodeJSON(resp, &p); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// OrderedPriorities returns the account's priorities from most to least
+// severe, so the first element is P1. The priorities are fetched once and
+// cached on the client; use ClearPriorityCache to force them to be refetched.
+func (c *Client) OrderedPriorities(ctx context.Context) ([]PriorityProperty, error) {
+	c.prioritiesMu.Lock()
+	defer c.prioritiesMu.Unlock()
+
+	if c.priorities == nil {
+		priorities := make([]PriorityProperty, 0)
+
+		responseHandler := func(response *http.Response) (APIListObject, error) {
+			var result Priorities
+			if err := c.decodeJSON(response, &result); err != nil {
+				return APIListObject{}, err
+			}
+
+			priorities = append(priorities, result.Priorities...)
+
+			return APIListObject{
+				More:   result.More,
+				Offset: result.Offset,
+				Limit:  result.Limit,
+			}, nil
+		}
+
+		if err := c.pagedGet(ctx, "/priorities", responseHandler); err != nil {
+			return nil, err
+		}
+
+		c.priorities = priorities
+	}
+
+	// hand out a copy so callers can't modify the cache
+	p := make([]PriorityProperty, len(c.priorities))
+	copy(p, c.priorities)
+
+	return p, nil
+}
+
+// PriorityByName returns the account's priority with the given name, compared
+// case-insensitively.
+func (c *Client) PriorityByName(ctx context.Context, name string) (*PriorityProperty, error) {
+	priorities, err := c.OrderedPriorities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range priorities {
+		if strings.EqualFold(p.Name, name) {
+			return &p, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no priority named %q", name)
+}
+
+// PriorityByRank returns the account's priority at the given rank, where 1 is
+// the most severe.
+func (c *Client) PriorityByRank(ctx context.Context, rank int) (*PriorityProperty, error) {
+	priorities, err := c.OrderedPriorities(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	if rank < 1 || rank > len(priorities) {
+		return nil, fmt.Errorf("priority rank %d out of range, account has %d priorities", rank, len(priorities))
+	}
+
+	p := priorities[rank-1]
 	return &p, nil
 }
+
+// ClearPriorityCache discards the priorities cached by OrderedPriorities,
+// PriorityByName, and PriorityByRank.
+func (c *Client) ClearPriorityCache() {
+	c.prioritiesMu.Lock()
+	c.priorities = nil
+	c.prioritiesMu.Unlock()
+}
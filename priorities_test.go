@@ -1,11 +1,12 @@
 package pagerduty
 
 import (
+	"context"
 	"net/http"
 	"testing"
 )
 
-// ListMaintenanceWindows
+// ListPriorities
 func TestPriorities_List(t *testing.T) {
 	setup()
 	defer teardown()
@@ -37,3 +38,77 @@ func TestPriorities_List(t *testing.T) {
 	}
 	testEqual(t, want, res)
 }
+
+// ListPrioritiesWithContext
+func TestPriorities_ListWithContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/priorities", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Write([]byte(`{"priorities": [{"id": "P1ID", "name": "P1", "description": "Major outage", "color": "a8171c"}]}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.ListPrioritiesWithContext(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []PriorityProperty{
+		{
+			APIObject:   APIObject{ID: "P1ID"},
+			Name:        "P1",
+			Description: "Major outage",
+			Color:       "a8171c",
+		},
+	}
+	testEqual(t, want, res.Priorities)
+}
+
+// PriorityByName and PriorityByRank
+func TestPriorities_Lookup(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests int
+	mux.HandleFunc("/priorities", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		requests++
+		w.Write([]byte(`{"priorities": [{"id": "P1ID", "name": "P1"}, {"id": "P2ID", "name": "P2"}], "more": false}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+	ctx := context.Background()
+
+	p, err := client.PriorityByName(ctx, "p2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, "P2ID", p.ID)
+
+	p, err = client.PriorityByRank(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, "P1ID", p.ID)
+
+	if requests != 1 {
+		t.Errorf("made %d requests, want 1 with the cache", requests)
+	}
+
+	_, err = client.PriorityByRank(ctx, 3)
+	testErrCheck(t, "PriorityByRank()", "out of range", err)
+
+	_, err = client.PriorityByName(ctx, "P5")
+	testErrCheck(t, "PriorityByName()", "no priority named", err)
+
+	client.ClearPriorityCache()
+	if _, err := client.OrderedPriorities(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("made %d requests, want 2 after clearing the cache", requests)
+	}
+}
@@ -0,0 +1,114 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-querystring/query"
+)
+
+// ResponsePlay is a package of responders, subscribers and conference details
+// that can be added to an incident in a single action.
+type ResponsePlay struct {
+	APIObject
+	Name               string      `json:"name,omitempty"`
+	Description        string      `json:"description,omitempty"`
+	Team               *APIObject  `json:"team,omitempty"`
+	Subscribers        []APIObject `json:"subscribers,omitempty"`
+	SubscribersMessage string      `json:"subscribers_message,omitempty"`
+	Responders         []APIObject `json:"responders,omitempty"`
+	RespondersMessage  string      `json:"responders_message,omitempty"`
+	Runnability        string      `json:"runnability,omitempty"`
+	ConferenceNumber   string      `json:"conference_number,omitempty"`
+	ConferenceURL      string      `json:"conference_url,omitempty"`
+	ConferenceType     string      `json:"conference_type,omitempty"`
+}
+
+// ListResponsePlaysResponse is the data structure returned from calling the
+// ListResponsePlays API endpoint.
+type ListResponsePlaysResponse struct {
+	APIListObject
+	ResponsePlays []ResponsePlay `json:"response_plays"`
+}
+
+// ListResponsePlaysOptions is the data structure used when calling the
+// ListResponsePlays API endpoint.
+type ListResponsePlaysOptions struct {
+	Query string `url:"query,omitempty"`
+
+	// FilterForManualRun only lists the response plays that can be run by hand
+	// on an incident.
+	FilterForManualRun bool `url:"filter_for_manual_run,omitempty"`
+}
+
+// ListResponsePlays lists existing response plays. from is the email address
+// of a valid user, which the API requires.
+func (c *Client) ListResponsePlays(ctx context.Context, from string, o ListResponsePlaysOptions) (*ListResponsePlaysResponse, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err = c.resolveFrom(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(ctx, http.MethodGet, "/response_plays?"+v.Encode(), nil, map[string]string{"From": from})
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListResponsePlaysResponse
+	return &result, c.decodeJSON(resp, &result)
+}
+
+// GetResponsePlay gets details about an existing response play. from is the
+// email address of a valid user, which the API requires.
+func (c *Client) GetResponsePlay(ctx context.Context, from, id string) (*ResponsePlay, error) {
+	from, err := c.resolveFrom(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(ctx, http.MethodGet, "/response_plays/"+id, nil, map[string]string{"From": from})
+	return getResponsePlayFromResponse(c, resp, err)
+}
+
+// RunResponsePlay runs a response play on an incident, adding the play's
+// responders, subscribers and conference details to it. from is the email
+// address of a valid user, which the API requires.
+func (c *Client) RunResponsePlay(ctx context.Context, from, responsePlayID, incidentID string) error {
+	d := map[string]APIObject{
+		"incident": {ID: incidentID, Type: "incident_reference"},
+	}
+
+	from, err := c.resolveFrom(ctx, from)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.post(ctx, "/response_plays/"+responsePlayID+"/run", d, map[string]string{"From": from})
+	return err
+}
+
+func getResponsePlayFromResponse(c *Client, resp *http.Response, err error) (*ResponsePlay, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	var target map[string]ResponsePlay
+	if dErr := c.decodeJSON(resp, &target); dErr != nil {
+		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
+	}
+
+	const rootNode = "response_play"
+
+	t, nodeOK := target[rootNode]
+	if !nodeOK {
+		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
+	}
+
+	return &t, nil
+}
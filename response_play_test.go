@@ -0,0 +1,88 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// List Response Plays
+func TestResponsePlay_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/response_plays", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "foo@bar.com", r.Header.Get("From"))
+		testEqual(t, "true", r.URL.Query().Get("filter_for_manual_run"))
+		w.Write([]byte(`{"response_plays": [{"id": "PRP1", "name": "Sev1", "conference_number": "+1 555-0100,,123#", "responders": [{"id": "PEP1", "type": "escalation_policy_reference"}]}]}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.ListResponsePlays(context.Background(), "foo@bar.com", ListResponsePlaysOptions{FilterForManualRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []ResponsePlay{
+		{
+			APIObject:        APIObject{ID: "PRP1"},
+			Name:             "Sev1",
+			ConferenceNumber: "+1 555-0100,,123#",
+			Responders:       []APIObject{{ID: "PEP1", Type: "escalation_policy_reference"}},
+		},
+	}
+	testEqual(t, want, res.ResponsePlays)
+}
+
+// Get Response Play
+func TestResponsePlay_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/response_plays/PRP1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Write([]byte(`{"response_play": {"id": "PRP1", "name": "Sev1", "subscribers": [{"id": "PUSER1", "type": "user_reference"}]}}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.GetResponsePlay(context.Background(), "foo@bar.com", "PRP1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &ResponsePlay{
+		APIObject:   APIObject{ID: "PRP1"},
+		Name:        "Sev1",
+		Subscribers: []APIObject{{ID: "PUSER1", Type: "user_reference"}},
+	}
+	testEqual(t, want, res)
+}
+
+// Run Response Play
+func TestResponsePlay_Run(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/response_plays/PRP1/run", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testEqual(t, "foo@bar.com", r.Header.Get("From"))
+
+		var body map[string]APIObject
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		testEqual(t, APIObject{ID: "PINC1", Type: "incident_reference"}, body["incident"])
+
+		w.Write([]byte(`{"status": "ok"}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	if err := client.RunResponsePlay(context.Background(), "foo@bar.com", "PRP1", "PINC1"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,140 @@
+package pagerduty
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultRetryableMethods are the HTTP methods that are safe to retry without
+// risking a duplicate write. POST is deliberately left out, even when the
+// request has an Idempotency-Key header: the API doesn't document that it
+// de-duplicates on the header, so retrying a create can create the resource
+// twice.
+var defaultRetryableMethods = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodHead:   true,
+	http.MethodPut:    true,
+	http.MethodDelete: true,
+}
+
+// retryBaseDelay and retryMaxDelay bound the exponential backoff used between
+// attempts when the API doesn't say how long to wait.
+var (
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 30 * time.Second
+)
+
+// WithRetryPolicy makes the client retry requests that fail with a 429 or 5xx
+// response, up to maxRetries times after the first attempt. If
+// respectRetryAfter is true the client waits for as long as the response's
+// Retry-After header asks, otherwise it backs off exponentially. Waiting is
+// cut short if the request's context is canceled. Retries are opt-in and only
+// apply to the methods allowed by WithRetryableMethods; other 4xx responses
+// and network errors are never retried.
+func WithRetryPolicy(maxRetries int, respectRetryAfter bool) ClientOptions {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.respectRetryAfter = respectRetryAfter
+	}
+}
+
+// WithRetryableMethods overrides which HTTP methods the client is allowed to
+// retry, replacing the default of GET, HEAD, PUT, and DELETE.
+func WithRetryableMethods(methods ...string) ClientOptions {
+	return func(c *Client) {
+		c.retryableMethods = make(map[string]bool, len(methods))
+		for _, m := range methods {
+			c.retryableMethods[strings.ToUpper(m)] = true
+		}
+	}
+}
+
+// canRetry reports whether req may be sent again after a failed attempt.
+func (c *Client) canRetry(req *http.Request) bool {
+	methods := c.retryableMethods
+	if methods == nil {
+		methods = defaultRetryableMethods
+	}
+
+	return methods[req.Method]
+}
+
+// retryWait reports whether the failed attempt'th try of req should be retried,
+// and if so how long to wait before doing so.
+func (c *Client) retryWait(req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if attempt > c.maxRetries {
+		return 0, false
+	}
+
+	status, ok := errorStatusCode(err)
+	if !ok {
+		return 0, false
+	}
+
+	if status != http.StatusTooManyRequests && status < 500 {
+		return 0, false
+	}
+
+	if !c.canRetry(req) {
+		return 0, false
+	}
+
+	if c.respectRetryAfter && resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return d, true
+		}
+	}
+
+	d := retryBaseDelay << uint(attempt-1)
+	if d <= 0 || d > retryMaxDelay {
+		d = retryMaxDelay
+	}
+
+	return d, true
+}
+
+// errorStatusCode returns the HTTP status code of the response that caused
+// err, if err came from an API response rather than from the transport.
+func errorStatusCode(err error) (int, bool) {
+	var aerr APIError
+	if errors.As(err, &aerr) {
+		return aerr.StatusCode, true
+	}
+
+	var eerr EventsAPIError
+	if errors.As(err, &eerr) {
+		return eerr.StatusCode, true
+	}
+
+	return 0, false
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+
+	d := time.Until(t)
+	if d < 0 {
+		d = 0
+	}
+
+	return d, true
+}
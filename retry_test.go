@@ -0,0 +1,263 @@
+package pagerduty
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_canRetry(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []ClientOptions
+		method  string
+		idemKey string
+		want    bool
+	}{
+		{name: "default_get", method: http.MethodGet, want: true},
+		{name: "default_put", method: http.MethodPut, want: true},
+		{name: "default_delete", method: http.MethodDelete, want: true},
+		{name: "default_post", method: http.MethodPost, want: false},
+		{name: "default_post_idempotency_key", method: http.MethodPost, idemKey: "abc", want: false},
+		{
+			name:   "override_get_only",
+			opts:   []ClientOptions{WithRetryableMethods("get")},
+			method: http.MethodDelete,
+			want:   false,
+		},
+		{
+			name:   "override_post",
+			opts:   []ClientOptions{WithRetryableMethods(http.MethodPost)},
+			method: http.MethodPost,
+			want:   true,
+		},
+		{
+			name:    "override_none_idempotency_key",
+			opts:    []ClientOptions{WithRetryableMethods()},
+			method:  http.MethodPost,
+			idemKey: "abc",
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient("foo", tt.opts...)
+
+			req, err := http.NewRequest(tt.method, "https://api.pagerduty.com/services", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.idemKey != "" {
+				req.Header.Set("Idempotency-Key", tt.idemKey)
+			}
+
+			if got := c.canRetry(req); got != tt.want {
+				t.Fatalf("canRetry() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_retryPolicy(t *testing.T) {
+	defer func(d time.Duration) { retryBaseDelay = d }(retryBaseDelay)
+	retryBaseDelay = time.Millisecond
+
+	tests := []struct {
+		name         string
+		statuses     []int
+		method       string
+		headers      map[string]string
+		maxRetries   int
+		wantAttempts int
+		wantErr      string
+	}{
+		{
+			name:         "rate_limited_then_ok",
+			statuses:     []int{http.StatusTooManyRequests, http.StatusOK},
+			method:       http.MethodGet,
+			maxRetries:   3,
+			wantAttempts: 2,
+		},
+		{
+			name:         "server_error_exhausted",
+			statuses:     []int{http.StatusServiceUnavailable},
+			method:       http.MethodGet,
+			maxRetries:   2,
+			wantAttempts: 3,
+			wantErr:      "request failed after 3 attempts",
+		},
+		{
+			name:         "not_found_not_retried",
+			statuses:     []int{http.StatusNotFound},
+			method:       http.MethodGet,
+			maxRetries:   3,
+			wantAttempts: 1,
+			wantErr:      "status code 404",
+		},
+		{
+			name:         "post_not_retried",
+			statuses:     []int{http.StatusTooManyRequests},
+			method:       http.MethodPost,
+			maxRetries:   3,
+			wantAttempts: 1,
+			wantErr:      "status code 429",
+		},
+		{
+			name:         "post_with_idempotency_key_not_retried",
+			statuses:     []int{http.StatusServiceUnavailable},
+			method:       http.MethodPost,
+			headers:      map[string]string{"Idempotency-Key": "key-1"},
+			maxRetries:   3,
+			wantAttempts: 1,
+			wantErr:      "status code 503",
+		},
+		{
+			name:         "disabled",
+			statuses:     []int{http.StatusTooManyRequests},
+			method:       http.MethodGet,
+			wantAttempts: 1,
+			wantErr:      "status code 429",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			var attempts int
+			mux.HandleFunc("/services/1", func(w http.ResponseWriter, r *http.Request) {
+				status := tt.statuses[len(tt.statuses)-1]
+				if attempts < len(tt.statuses) {
+					status = tt.statuses[attempts]
+				}
+				attempts++
+
+				w.Header().Set("Retry-After", "0")
+				if status != http.StatusOK {
+					w.WriteHeader(status)
+					return
+				}
+				w.Write([]byte(`{"service": {"id": "1"}}`))
+			})
+
+			client := NewClient("foo", WithAPIEndpoint(server.URL), WithRetryPolicy(tt.maxRetries, true))
+
+			_, err := client.do(context.Background(), tt.method, "/services/1", nil, tt.headers)
+
+			if attempts != tt.wantAttempts {
+				t.Errorf("made %d attempts, want %d", attempts, tt.wantAttempts)
+			}
+
+			if !testErrCheck(t, "do()", tt.wantErr, err) {
+				var aerr APIError
+				if !errors.As(err, &aerr) {
+					t.Errorf("err = %v, want it to wrap an APIError", err)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_retryPolicyContextCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	client := NewClient("foo", WithAPIEndpoint(server.URL), WithRetryPolicy(3, true))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetServiceWithContext(ctx, "1", &GetServiceOptions{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestClient_idempotencyKeyNotRetried(t *testing.T) {
+	defer func(d time.Duration) { retryBaseDelay = d }(retryBaseDelay)
+	retryBaseDelay = time.Millisecond
+
+	setup()
+	defer teardown()
+
+	var attempts int
+	mux.HandleFunc("/services/PSVC1/integrations", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testEqual(t, "key-1", r.Header.Get("Idempotency-Key"))
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	client := NewClient("foo", WithAPIEndpoint(server.URL), WithRetryPolicy(3, false))
+
+	_, err := client.CreateIntegrationWithContext(context.Background(), "PSVC1", Integration{Name: "api"}, WithIdempotencyKey("key-1"))
+	testErrCheck(t, "CreateIntegrationWithContext()", "503", err)
+	testEqual(t, 1, attempts)
+}
+
+func TestClient_retryReplaysBody(t *testing.T) {
+	defer func(d time.Duration) { retryBaseDelay = d }(retryBaseDelay)
+	retryBaseDelay = time.Millisecond
+
+	setup()
+	defer teardown()
+
+	var bodies []string
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bodies = append(bodies, string(b))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"service": {"id": "PSVC1"}}`))
+	})
+
+	client := NewClient("foo", WithAPIEndpoint(server.URL), WithRetryPolicy(3, false), WithRetryableMethods(http.MethodPost))
+
+	s, err := client.CreateServiceWithContext(context.Background(), Service{Name: "api", EscalationPolicy: EscalationPolicy{APIObject: APIObject{ID: "PEP1"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "PSVC1", s.ID)
+	if len(bodies) != 2 {
+		t.Fatalf("got %d attempts, want 2", len(bodies))
+	}
+	if bodies[0] == "" || bodies[0] != bodies[1] {
+		t.Errorf("retried body = %q, want %q", bodies[1], bodies[0])
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d, ok := parseRetryAfter("5"); !ok || d != 5*time.Second {
+		t.Errorf("parseRetryAfter(5) = %s, %t, want 5s, true", d, ok)
+	}
+
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Error("parseRetryAfter(soon) should not be ok")
+	}
+
+	future := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if d, ok := parseRetryAfter(future); !ok || d <= 0 {
+		t.Errorf("parseRetryAfter(%s) = %s, %t, want a positive duration", future, d, ok)
+	}
+}
@@ -174,9 +174,7 @@ func (c *Client) ListRulesets() (*ListRulesetsResponse, error) {
 
 // CreateRuleset creates a new ruleset.
 func (c *Client) CreateRuleset(r *Ruleset) (*Ruleset, *http.Response, error) {
-	data := make(map[string]*Ruleset)
-	data["ruleset"] = r
-	resp, err := c.post(context.TODO(), "/rulesets", data, nil)
+	resp, err := c.post(context.TODO(), "/rulesets", wrapBody("ruleset", r), nil)
 	return getRulesetFromResponse(c, resp, err)
 }
 
@@ -194,9 +192,7 @@ func (c *Client) GetRuleset(id string) (*Ruleset, *http.Response, error) {
 
 // UpdateRuleset updates a ruleset.
 func (c *Client) UpdateRuleset(r *Ruleset) (*Ruleset, *http.Response, error) {
-	v := make(map[string]*Ruleset)
-	v["ruleset"] = r
-	resp, err := c.put(context.TODO(), "/rulesets/"+r.ID, v, nil)
+	resp, err := c.put(context.TODO(), "/rulesets/"+r.ID, wrapBody("ruleset", r), nil)
 	return getRulesetFromResponse(c, resp, err)
 }
 
@@ -263,17 +259,13 @@ func (c *Client) DeleteRulesetRule(rulesetID, ruleID string) error {
 
 // CreateRulesetRule creates a new rule for a ruleset.
 func (c *Client) CreateRulesetRule(rulesetID string, rule *RulesetRule) (*RulesetRule, *http.Response, error) {
-	data := make(map[string]*RulesetRule)
-	data["rule"] = rule
-	resp, err := c.post(context.TODO(), "/rulesets/"+rulesetID+"/rules/", data, nil)
+	resp, err := c.post(context.TODO(), "/rulesets/"+rulesetID+"/rules/", wrapBody("rule", rule), nil)
 	return getRuleFromResponse(c, resp, err)
 }
 
 // UpdateRulesetRule updates a rule.
 func (c *Client) UpdateRulesetRule(rulesetID, ruleID string, r *RulesetRule) (*RulesetRule, *http.Response, error) {
-	v := make(map[string]*RulesetRule)
-	v["rule"] = r
-	resp, err := c.put(context.TODO(), "/rulesets/"+rulesetID+"/rules/"+ruleID, v, nil)
+	resp, err := c.put(context.TODO(), "/rulesets/"+rulesetID+"/rules/"+ruleID, wrapBody("rule", r), nil)
 	return getRuleFromResponse(c, resp, err)
 }
 
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -55,6 +57,11 @@ type Schedule struct {
 type ListSchedulesOptions struct {
 	APIListObject
 	Query string `url:"query,omitempty"`
+
+	// ListAll makes ListSchedules return every schedule in a single response, with
+	// Limit as the page size. Offset is ignored, and ListAll isn't sent to the
+	// API.
+	ListAll bool `url:"-"`
 }
 
 // ListSchedulesResponse is the data structure returned from calling the ListSchedules API endpoint.
@@ -70,11 +77,32 @@ type UserReference struct {
 
 // ListSchedules lists the on-call schedules.
 func (c *Client) ListSchedules(o ListSchedulesOptions) (*ListSchedulesResponse, error) {
+	return c.ListSchedulesWithContext(context.TODO(), o)
+}
+
+// ListSchedulesWithContext lists the on-call schedules.
+func (c *Client) ListSchedulesWithContext(ctx context.Context, o ListSchedulesOptions) (*ListSchedulesResponse, error) {
+	if o.ListAll {
+		var all ListSchedulesResponse
+		responseHandler := func(response *http.Response) (APIListObject, error) {
+			var result ListSchedulesResponse
+			if err := c.decodeJSON(response, &result); err != nil {
+				return APIListObject{}, err
+			}
+			all.Schedules = append(all.Schedules, result.Schedules...)
+			return result.APIListObject, nil
+		}
+		if err := c.pagedGetAll(ctx, "/schedules", o, responseHandler); err != nil {
+			return nil, err
+		}
+		return &all, nil
+	}
+
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.get(context.TODO(), "/schedules?"+v.Encode())
+	resp, err := c.get(ctx, "/schedules?"+v.Encode())
 	if err != nil {
 		return nil, err
 	}
@@ -84,9 +112,12 @@ func (c *Client) ListSchedules(o ListSchedulesOptions) (*ListSchedulesResponse,
 
 // CreateSchedule creates a new on-call schedule.
 func (c *Client) CreateSchedule(s Schedule) (*Schedule, error) {
-	data := make(map[string]Schedule)
-	data["schedule"] = s
-	resp, err := c.post(context.TODO(), "/schedules", data, nil)
+	return c.CreateScheduleWithContext(context.TODO(), s)
+}
+
+// CreateScheduleWithContext creates a new on-call schedule.
+func (c *Client) CreateScheduleWithContext(ctx context.Context, s Schedule) (*Schedule, error) {
+	resp, err := c.post(ctx, "/schedules", wrapBody("schedule", s), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -107,15 +138,18 @@ func (c *Client) PreviewSchedule(s Schedule, o PreviewScheduleOptions) error {
 	if err != nil {
 		return err
 	}
-	var data map[string]Schedule
-	data["schedule"] = s
-	_, err = c.post(context.TODO(), "/schedules/preview?"+v.Encode(), data, nil)
+	_, err = c.post(context.TODO(), "/schedules/preview?"+v.Encode(), wrapBody("schedule", s), nil)
 	return err
 }
 
 // DeleteSchedule deletes an on-call schedule.
 func (c *Client) DeleteSchedule(id string) error {
-	_, err := c.delete(context.TODO(), "/schedules/"+id)
+	return c.DeleteScheduleWithContext(context.TODO(), id)
+}
+
+// DeleteScheduleWithContext deletes an on-call schedule.
+func (c *Client) DeleteScheduleWithContext(ctx context.Context, id string) error {
+	_, err := c.delete(ctx, "/schedules/"+id)
 	return err
 }
 
@@ -129,17 +163,164 @@ type GetScheduleOptions struct {
 
 // GetSchedule shows detailed information about a schedule, including entries for each layer and sub-schedule.
 func (c *Client) GetSchedule(id string, o GetScheduleOptions) (*Schedule, error) {
+	return c.GetScheduleWithContext(context.TODO(), id, o)
+}
+
+// GetScheduleWithContext shows detailed information about a schedule,
+// including entries for each layer and sub-schedule. The rendered entries of
+// the final schedule, the override sub-schedule, and each layer cover the
+// window given by o.Since and o.Until.
+func (c *Client) GetScheduleWithContext(ctx context.Context, id string, o GetScheduleOptions) (*Schedule, error) {
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, fmt.Errorf("Could not parse values for query: %v", err)
 	}
-	resp, err := c.get(context.TODO(), "/schedules/"+id+"?"+v.Encode())
+	resp, err := c.get(ctx, "/schedules/"+id+"?"+v.Encode())
 	if err != nil {
 		return nil, err
 	}
 	return getScheduleFromResponse(c, resp)
 }
 
+// ScheduleCoverageDiff is a span of time where the final schedule puts
+// someone on call who none of the schedule's layers have on call, usually
+// because of an override.
+type ScheduleCoverageDiff struct {
+	Start time.Time
+	End   time.Time
+
+	// Scheduled are the users the layers have on call during the span.
+	Scheduled []APIObject
+
+	// Actual is the user the final schedule has on call during the span, or
+	// nil if nobody is on call.
+	Actual *APIObject
+}
+
+type scheduleSpan struct {
+	start, end time.Time
+	user       APIObject
+}
+
+func parseRenderedEntries(entries []RenderedScheduleEntry) ([]scheduleSpan, error) {
+	spans := make([]scheduleSpan, 0, len(entries))
+
+	for _, e := range entries {
+		start, err := time.Parse(time.RFC3339, e.Start)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse rendered entry start: %w", err)
+		}
+
+		end, err := time.Parse(time.RFC3339, e.End)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse rendered entry end: %w", err)
+		}
+
+		spans = append(spans, scheduleSpan{start: start, end: end, user: e.User})
+	}
+
+	return spans, nil
+}
+
+// DiffScheduleCoverage compares the rendered final schedule of s against the
+// rendered entries of its layers and returns the spans where they disagree,
+// in chronological order. s must have been fetched with a Since and Until so
+// that the rendered entries are populated.
+func DiffScheduleCoverage(s Schedule) ([]ScheduleCoverageDiff, error) {
+	final, err := parseRenderedEntries(s.FinalSchedule.RenderedScheduleEntries)
+	if err != nil {
+		return nil, err
+	}
+
+	var layers []scheduleSpan
+	for _, l := range s.ScheduleLayers {
+		spans, err := parseRenderedEntries(l.RenderedScheduleEntries)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, spans...)
+	}
+
+	// split the window at every point where any entry starts or ends, so
+	// that who is on call is constant within each segment
+	var bounds []time.Time
+	for _, sp := range append(append([]scheduleSpan{}, final...), layers...) {
+		bounds = append(bounds, sp.start, sp.end)
+	}
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i].Before(bounds[j]) })
+
+	var diffs []ScheduleCoverageDiff
+
+	for i := 0; i+1 < len(bounds); i++ {
+		start, end := bounds[i], bounds[i+1]
+		if !start.Before(end) {
+			continue
+		}
+
+		var actual *APIObject
+		for _, sp := range final {
+			if !sp.start.After(start) && sp.end.After(start) {
+				u := sp.user
+				actual = &u
+				break
+			}
+		}
+
+		var scheduled []APIObject
+		matched := false
+		for _, sp := range layers {
+			if !sp.start.After(start) && sp.end.After(start) {
+				scheduled = append(scheduled, sp.user)
+				if actual != nil && sp.user.ID == actual.ID {
+					matched = true
+				}
+			}
+		}
+
+		if matched || (actual == nil && len(scheduled) == 0) {
+			continue
+		}
+
+		// extend the previous diff rather than starting a new one when
+		// nothing changed between the two segments
+		if n := len(diffs); n > 0 {
+			prev := &diffs[n-1]
+			if prev.End.Equal(start) && sameScheduleUser(prev.Actual, actual) && sameScheduleUsers(prev.Scheduled, scheduled) {
+				prev.End = end
+				continue
+			}
+		}
+
+		diffs = append(diffs, ScheduleCoverageDiff{
+			Start:     start,
+			End:       end,
+			Scheduled: scheduled,
+			Actual:    actual,
+		})
+	}
+
+	return diffs, nil
+}
+
+func sameScheduleUser(a, b *APIObject) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.ID == b.ID
+}
+
+func sameScheduleUsers(a, b []APIObject) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].ID != b[i].ID {
+			return false
+		}
+	}
+	return true
+}
+
 // UpdateScheduleOptions is the data structure used when calling the UpdateSchedule API endpoint.
 type UpdateScheduleOptions struct {
 	Overflow bool `url:"overflow,omitempty"`
@@ -147,9 +328,12 @@ type UpdateScheduleOptions struct {
 
 // UpdateSchedule updates an existing on-call schedule.
 func (c *Client) UpdateSchedule(id string, s Schedule) (*Schedule, error) {
-	v := make(map[string]Schedule)
-	v["schedule"] = s
-	resp, err := c.put(context.TODO(), "/schedules/"+id, v, nil)
+	return c.UpdateScheduleWithContext(context.TODO(), id, s)
+}
+
+// UpdateScheduleWithContext updates an existing on-call schedule.
+func (c *Client) UpdateScheduleWithContext(ctx context.Context, id string, s Schedule) (*Schedule, error) {
+	resp, err := c.put(ctx, "/schedules/"+id, wrapBody("schedule", s), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -195,18 +379,57 @@ func (c *Client) ListOverrides(id string, o ListOverridesOptions) (*ListOverride
 
 // CreateOverride creates an override for a specific user covering the specified time range.
 func (c *Client) CreateOverride(id string, o Override) (*Override, error) {
-	data := make(map[string]Override)
-	data["override"] = o
-	resp, err := c.post(context.TODO(), "/schedules/"+id+"/overrides", data, nil)
+	return c.CreateScheduleOverride(context.TODO(), id, o)
+}
+
+// DeleteOverride removes an override.
+func (c *Client) DeleteOverride(scheduleID, overrideID string) error {
+	return c.DeleteScheduleOverride(context.TODO(), scheduleID, overrideID)
+}
+
+// ListScheduleOverrides lists the overrides of a schedule that overlap the
+// window from since to until.
+func (c *Client) ListScheduleOverrides(ctx context.Context, scheduleID string, since, until time.Time) ([]Override, error) {
+	if !until.After(since) {
+		return nil, fmt.Errorf("until %s must be after since %s", until.Format(time.RFC3339), since.Format(time.RFC3339))
+	}
+
+	v, err := query.Values(ListOverridesOptions{
+		Since: since.Format(time.RFC3339),
+		Until: until.Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.get(ctx, "/schedules/"+scheduleID+"/overrides?"+v.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListOverridesResponse
+	if err := c.decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Overrides, nil
+}
+
+// CreateScheduleOverride creates an override for a specific user covering the
+// time range of o. If the API rejects the override, for example because it
+// ends in the past, the error is an APIError.
+func (c *Client) CreateScheduleOverride(ctx context.Context, scheduleID string, o Override) (*Override, error) {
+	resp, err := c.post(ctx, "/schedules/"+scheduleID+"/overrides", wrapBody("override", o), nil)
 	if err != nil {
 		return nil, err
 	}
 	return getOverrideFromResponse(c, resp)
 }
 
-// DeleteOverride removes an override.
-func (c *Client) DeleteOverride(scheduleID, overrideID string) error {
-	_, err := c.delete(context.TODO(), "/schedules/"+scheduleID+"/overrides/"+overrideID)
+// DeleteScheduleOverride removes an override, or truncates it to end now if
+// it has already started.
+func (c *Client) DeleteScheduleOverride(ctx context.Context, scheduleID, overrideID string) error {
+	_, err := c.delete(ctx, "/schedules/"+scheduleID+"/overrides/"+overrideID)
 	return err
 }
 
@@ -1,8 +1,12 @@
 package pagerduty
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"testing"
+	"time"
 )
 
 // ListSchedules
@@ -74,6 +78,29 @@ func TestSchedule_Create(t *testing.T) {
 }
 
 // TODO: Preview a schedule -- should this function be changed to actually return a preview?
+func TestSchedule_Preview(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/schedules/preview", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var got map[string]Schedule
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if got["schedule"].Name != "foo" {
+			t.Errorf("schedule name = %q, want %q", got["schedule"].Name, "foo")
+		}
+		w.Write([]byte(`{"schedule": {"name": "foo"}}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+	err := client.PreviewSchedule(Schedule{Name: "foo"}, PreviewScheduleOptions{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
 
 // Delete a schedule
 func TestSchedule_Delete(t *testing.T) {
@@ -162,6 +189,51 @@ func TestSchedule_Update(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+// Create a schedule with layers and restrictions
+func TestSchedule_CreateWithContextLayers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	layer := ScheduleLayer{
+		Name:                      "Weekdays",
+		Start:                     "2020-10-01T00:00:00Z",
+		RotationVirtualStart:      "2020-10-01T09:00:00Z",
+		RotationTurnLengthSeconds: 86400,
+		Users:                     []UserReference{{User: APIObject{ID: "U1", Type: "user_reference"}}},
+		Restrictions: []Restriction{
+			{Type: "daily_restriction", StartTimeOfDay: "09:00:00", DurationSeconds: 28800},
+		},
+	}
+
+	mux.HandleFunc("/schedules", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body map[string]Schedule
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		testEqual(t, []ScheduleLayer{layer}, body["schedule"].ScheduleLayers)
+
+		w.Write([]byte(`{"schedule": {"id": "1", "name": "foo"}}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.CreateScheduleWithContext(context.Background(), Schedule{Name: "foo", ScheduleLayers: []ScheduleLayer{layer}})
+
+	want := &Schedule{
+		APIObject: APIObject{
+			ID: "1",
+		},
+		Name: "foo",
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, want, res)
+}
+
 // List overrides
 func TestSchedule_ListOverrides(t *testing.T) {
 	setup()
@@ -250,6 +322,67 @@ func TestSchedule_DeleteOverride(t *testing.T) {
 	}
 }
 
+// List schedule overrides in a window
+func TestSchedule_ListScheduleOverrides(t *testing.T) {
+	setup()
+	defer teardown()
+
+	since := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	until := since.Add(24 * time.Hour)
+
+	mux.HandleFunc("/schedules/1/overrides", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "2020-10-01T00:00:00Z", r.URL.Query().Get("since"))
+		testEqual(t, "2020-10-02T00:00:00Z", r.URL.Query().Get("until"))
+		w.Write([]byte(`{"overrides": [{"id": "O1", "start": "2020-10-01T09:00:00Z", "end": "2020-10-01T17:00:00Z", "user": {"id": "U1"}}]}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.ListScheduleOverrides(context.Background(), "1", since, until)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Override{
+		{
+			ID:    "O1",
+			Start: "2020-10-01T09:00:00Z",
+			End:   "2020-10-01T17:00:00Z",
+			User:  APIObject{ID: "U1"},
+		},
+	}
+	testEqual(t, want, res)
+
+	_, err = client.ListScheduleOverrides(context.Background(), "1", until, since)
+	testErrCheck(t, "ListScheduleOverrides()", "must be after since", err)
+}
+
+// Create a schedule override that the API rejects
+func TestSchedule_CreateScheduleOverrideAPIError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/schedules/1/overrides", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": {"code": 2001, "message": "Invalid Input Provided", "errors": ["Override must end in the future"]}}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	_, err := client.CreateScheduleOverride(context.Background(), "1", Override{Start: "2020-01-01T00:00:00Z", End: "2020-01-02T00:00:00Z"})
+
+	var aerr APIError
+	if !errors.As(err, &aerr) {
+		t.Fatalf("CreateScheduleOverride() error = %v, want an APIError", err)
+	}
+
+	testEqual(t, http.StatusBadRequest, aerr.StatusCode)
+	testEqual(t, []string{"Override must end in the future"}, aerr.APIError.ErrorObject.Errors)
+}
+
 // List users on call
 func TestSchedule_ListOnCallUsers(t *testing.T) {
 	setup()
@@ -284,3 +417,44 @@ func TestSchedule_ListOnCallUsers(t *testing.T) {
 	}
 	testEqual(t, want, res)
 }
+
+// DiffScheduleCoverage
+func TestDiffScheduleCoverage(t *testing.T) {
+	entry := func(start, end, user string) RenderedScheduleEntry {
+		return RenderedScheduleEntry{Start: start, End: end, User: APIObject{ID: user}}
+	}
+
+	s := Schedule{
+		ScheduleLayers: []ScheduleLayer{
+			{
+				RenderedScheduleEntries: []RenderedScheduleEntry{
+					entry("2020-10-01T00:00:00Z", "2020-10-01T12:00:00Z", "U1"),
+					entry("2020-10-01T12:00:00Z", "2020-10-02T00:00:00Z", "U2"),
+				},
+			},
+		},
+		FinalSchedule: ScheduleLayer{
+			RenderedScheduleEntries: []RenderedScheduleEntry{
+				entry("2020-10-01T00:00:00Z", "2020-10-01T06:00:00Z", "U1"),
+				entry("2020-10-01T06:00:00Z", "2020-10-01T09:00:00Z", "U3"),
+				entry("2020-10-01T09:00:00Z", "2020-10-01T12:00:00Z", "U3"),
+				entry("2020-10-01T12:00:00Z", "2020-10-02T00:00:00Z", "U2"),
+			},
+		},
+	}
+
+	res, err := DiffScheduleCoverage(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []ScheduleCoverageDiff{
+		{
+			Start:     time.Date(2020, 10, 1, 6, 0, 0, 0, time.UTC),
+			End:       time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC),
+			Scheduled: []APIObject{{ID: "U1"}},
+			Actual:    &APIObject{ID: "U3"},
+		},
+	}
+	testEqual(t, want, res)
+}
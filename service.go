@@ -2,12 +2,17 @@ package pagerduty
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"io/ioutil"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/go-querystring/query"
-	log "github.com/sirupsen/logrus"
 )
 
 // Integration is an endpoint (like Nagios, email, or an API call) that generates events, which are normalized and de-duplicated by PagerDuty to create incidents.
@@ -20,6 +25,86 @@ type Integration struct {
 	Type             string     `json:"type,omitempty"`
 	IntegrationKey   string     `json:"integration_key,omitempty"`
 	IntegrationEmail string     `json:"integration_email,omitempty"`
+
+	// Enabled reports whether the integration accepts events. It is nil when
+	// the API doesn't return it.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// The fields below configure how an email integration turns the emails it
+	// receives into incidents.
+	EmailIncidentCreation string                   `json:"email_incident_creation,omitempty"`
+	EmailFilterMode       string                   `json:"email_filter_mode,omitempty"`
+	EmailParsers          []IntegrationEmailParser `json:"email_parsers,omitempty"`
+	EmailParsingFallback  string                   `json:"email_parsing_fallback,omitempty"`
+	EmailFilters          []IntegrationEmailFilter `json:"email_filters,omitempty"`
+}
+
+// IntegrationEmailFilter is a rule an email must match for an email
+// integration to accept it, depending on the integration's EmailFilterMode.
+type IntegrationEmailFilter struct {
+	ID             string `json:"id,omitempty"`
+	SubjectMode    string `json:"subject_mode,omitempty"`
+	SubjectRegex   string `json:"subject_regex,omitempty"`
+	BodyMode       string `json:"body_mode,omitempty"`
+	BodyRegex      string `json:"body_regex,omitempty"`
+	FromEmailMode  string `json:"from_email_mode,omitempty"`
+	FromEmailRegex string `json:"from_email_regex,omitempty"`
+}
+
+// IntegrationEmailParser triggers or resolves an incident when an email
+// matches its predicate, extracting values such as the incident key from it.
+type IntegrationEmailParser struct {
+	ID              *int                             `json:"id,omitempty"`
+	Action          string                           `json:"action"`
+	MatchPredicate  IntegrationEmailMatchPredicate   `json:"match_predicate"`
+	ValueExtractors []IntegrationEmailValueExtractor `json:"value_extractors,omitempty"`
+}
+
+// IntegrationEmailMatchPredicate is the condition of an email parser. The
+// "any", "all" and "not" types combine their Children, the others match part
+// of the email against Matcher.
+type IntegrationEmailMatchPredicate struct {
+	Type     string                           `json:"type"`
+	Matcher  string                           `json:"matcher,omitempty"`
+	Part     string                           `json:"part,omitempty"`
+	Children []IntegrationEmailMatchPredicate `json:"children,omitempty"`
+}
+
+// IntegrationEmailValueExtractor extracts the value named ValueName from part
+// of an email, either with Regex or between StartsAfter and EndsBefore.
+type IntegrationEmailValueExtractor struct {
+	Type        string `json:"type"`
+	Part        string `json:"part"`
+	ValueName   string `json:"value_name"`
+	Regex       string `json:"regex,omitempty"`
+	StartsAfter string `json:"starts_after,omitempty"`
+	EndsBefore  string `json:"ends_before,omitempty"`
+}
+
+// The common values of the Type field of Integration.
+const (
+	IntegrationTypeEventsAPIV2      = "events_api_v2_inbound_integration"
+	IntegrationTypeEventsAPIV1      = "generic_events_api_inbound_integration"
+	IntegrationTypeEmail            = "generic_email_inbound_integration"
+	IntegrationTypeVendor           = "generic_integration_inbound_integration"
+	IntegrationTypeKeynote          = "keynote_inbound_integration"
+	IntegrationTypeNagios           = "nagios_inbound_integration"
+	IntegrationTypePingdom          = "pingdom_inbound_integration"
+	IntegrationTypeSQLMonitor       = "sql_monitor_inbound_integration"
+	IntegrationTypeEventTransformer = "event_transformer_api_inbound_integration"
+)
+
+// NewEventsV2Integration returns an Events API v2 integration named name. The
+// vendor is only set when vendorID isn't empty.
+func NewEventsV2Integration(name string, vendorID string) Integration {
+	i := Integration{
+		Name: name,
+		Type: IntegrationTypeEventsAPIV2,
+	}
+	if vendorID != "" {
+		i.Vendor = &APIObject{ID: vendorID, Type: "vendor_reference"}
+	}
+	return i
 }
 
 // InlineModel represents when a scheduled action will occur.
@@ -35,6 +120,81 @@ type ScheduledAction struct {
 	ToUrgency string      `json:"to_urgency"`
 }
 
+// ScheduledActionUrgencyChange is the only type of scheduled action supported
+// by the API.
+const ScheduledActionUrgencyChange = "urgency_change"
+
+// Named times that a scheduled action can occur at, relative to the service's
+// support hours.
+const (
+	ScheduledActionSupportHoursStart = "support_hours_start"
+	ScheduledActionSupportHoursEnd   = "support_hours_end"
+)
+
+// ScheduledActionUrgencyHigh is the urgency a scheduled action changes
+// incidents to. The API doesn't accept any other value.
+const ScheduledActionUrgencyHigh = "high"
+
+// NewScheduledAction returns an urgency change action that occurs at the named
+// time at, which must be ScheduledActionSupportHoursStart or
+// ScheduledActionSupportHoursEnd. Scheduled actions are always relative to the
+// service's support hours, so there's no way to schedule one at an arbitrary
+// point in time.
+func NewScheduledAction(at, toUrgency string) (ScheduledAction, error) {
+	a := ScheduledAction{
+		Type: ScheduledActionUrgencyChange,
+		At: InlineModel{
+			Type: "named_time",
+			Name: at,
+		},
+		ToUrgency: toUrgency,
+	}
+
+	if err := validateScheduledAction(a); err != nil {
+		return ScheduledAction{}, err
+	}
+
+	return a, nil
+}
+
+// SupportHoursStartAction returns the scheduled action that raises the
+// urgency of a service's open low urgency incidents to high when its support
+// hours start each day.
+func SupportHoursStartAction() ScheduledAction {
+	a, _ := NewScheduledAction(ScheduledActionSupportHoursStart, ScheduledActionUrgencyHigh)
+	return a
+}
+
+// SupportHoursEndAction returns the scheduled action that raises the urgency
+// of a service's open low urgency incidents to high when its support hours
+// end each day.
+func SupportHoursEndAction() ScheduledAction {
+	a, _ := NewScheduledAction(ScheduledActionSupportHoursEnd, ScheduledActionUrgencyHigh)
+	return a
+}
+
+func validateScheduledAction(a ScheduledAction) error {
+	if a.Type != ScheduledActionUrgencyChange {
+		return fmt.Errorf("invalid scheduled action type %q, must be %q", a.Type, ScheduledActionUrgencyChange)
+	}
+
+	if a.At.Type != "named_time" {
+		return fmt.Errorf("invalid scheduled action time type %q, must be %q", a.At.Type, "named_time")
+	}
+
+	switch a.At.Name {
+	case ScheduledActionSupportHoursStart, ScheduledActionSupportHoursEnd:
+	default:
+		return fmt.Errorf("invalid scheduled action time %q, must be %q or %q", a.At.Name, ScheduledActionSupportHoursStart, ScheduledActionSupportHoursEnd)
+	}
+
+	if a.ToUrgency != ScheduledActionUrgencyHigh {
+		return fmt.Errorf("invalid scheduled action urgency %q, must be %q", a.ToUrgency, ScheduledActionUrgencyHigh)
+	}
+
+	return nil
+}
+
 // IncidentUrgencyType are the incidents urgency during or outside support hours.
 type IncidentUrgencyType struct {
 	Type    string `json:"type,omitempty"`
@@ -50,6 +210,46 @@ type SupportHours struct {
 	DaysOfWeek []uint `json:"days_of_week,omitempty"`
 }
 
+// SupportHoursFixedTimePerDay is the only type of support hours supported by
+// the API.
+const SupportHoursFixedTimePerDay = "fixed_time_per_day"
+
+// validateSupportHours checks the support hours before they are sent, since
+// the API can accept malformed ones that then never take effect. Days of the
+// week are numbered 1 (Monday) to 7 (Sunday), and times are formatted as
+// hh:mm:ss.
+func validateSupportHours(h *SupportHours) error {
+	if h == nil || h.Type == "" {
+		return nil
+	}
+
+	if h.Type != SupportHoursFixedTimePerDay {
+		return fmt.Errorf("invalid support hours type %q, must be %q", h.Type, SupportHoursFixedTimePerDay)
+	}
+
+	if h.Timezone == "" {
+		return fmt.Errorf("support hours must have a time zone")
+	}
+
+	for _, t := range []string{h.StartTime, h.EndTime} {
+		if _, err := time.Parse("15:04:05", t); err != nil {
+			return fmt.Errorf("invalid support hours time %q, must be formatted as hh:mm:ss", t)
+		}
+	}
+
+	if len(h.DaysOfWeek) == 0 {
+		return fmt.Errorf("support hours must have at least one day of the week")
+	}
+
+	for _, d := range h.DaysOfWeek {
+		if d < 1 || d > 7 {
+			return fmt.Errorf("invalid support hours day of the week %d, must be between 1 (Monday) and 7 (Sunday)", d)
+		}
+	}
+
+	return nil
+}
+
 // IncidentUrgencyRule is the default urgency for new incidents.
 type IncidentUrgencyRule struct {
 	Type                string               `json:"type,omitempty"`
@@ -96,6 +296,7 @@ type Service struct {
 	Description             string                   `json:"description,omitempty"`
 	AutoResolveTimeout      *uint                    `json:"auto_resolve_timeout,omitempty"`
 	AcknowledgementTimeout  *uint                    `json:"acknowledgement_timeout,omitempty"`
+	CreatedAt               string                   `json:"-"`
 	CreateAt                string                   `json:"created_at,omitempty"`
 	Status                  string                   `json:"status,omitempty"`
 	LastIncidentTimestamp   string                   `json:"last_incident_timestamp,omitempty"`
@@ -109,6 +310,98 @@ type Service struct {
 	AlertGrouping           string                   `json:"alert_grouping,omitempty"`
 	AlertGroupingTimeout    *uint                    `json:"alert_grouping_timeout,omitempty"`
 	AlertGroupingParameters *AlertGroupingParameters `json:"alert_grouping_parameters,omitempty"`
+
+	// AlertGroupingActive is reported by the API when it knows whether alert
+	// grouping is actually in effect on the service, which for intelligent
+	// grouping may lag behind the configuration while the model is learning.
+	// It is nil on accounts that don't return the field.
+	AlertGroupingActive *bool `json:"alert_grouping_active,omitempty"`
+
+	AutoPauseNotificationsParameters *AutoPauseNotificationsParameters `json:"auto_pause_notifications_parameters,omitempty"`
+}
+
+// The values of the Status field of Service. Only ServiceStatusActive and
+// ServiceStatusDisabled can be set; the others are derived by PagerDuty from
+// the service's open incidents and maintenance windows.
+const (
+	ServiceStatusActive      = "active"
+	ServiceStatusWarning     = "warning"
+	ServiceStatusCritical    = "critical"
+	ServiceStatusMaintenance = "maintenance"
+	ServiceStatusDisabled    = "disabled"
+)
+
+// ValidServiceStatus reports whether status is one of the ServiceStatus
+// constants.
+func ValidServiceStatus(status string) bool {
+	switch status {
+	case ServiceStatusActive, ServiceStatusWarning, ServiceStatusCritical, ServiceStatusMaintenance, ServiceStatusDisabled:
+		return true
+	default:
+		return false
+	}
+}
+
+// The values of the AlertCreation field of Service, which is whether the
+// service creates alerts along with its incidents, as alert grouping needs.
+const (
+	AlertCreationCreateIncidents          = "create_incidents"
+	AlertCreationCreateAlertsAndIncidents = "create_alerts_and_incidents"
+)
+
+// ValidAlertCreation reports whether alertCreation is one of the
+// AlertCreation constants.
+func ValidAlertCreation(alertCreation string) bool {
+	return alertCreation == AlertCreationCreateIncidents || alertCreation == AlertCreationCreateAlertsAndIncidents
+}
+
+// AutoPauseNotificationsParameters defines whether notifications for
+// transient alerts on the service are paused, and for how long.
+type AutoPauseNotificationsParameters struct {
+	Enabled bool `json:"enabled"`
+
+	// Timeout is the number of seconds to pause notifications for. It must
+	// be one of the AutoPauseTimeout constants.
+	Timeout uint `json:"timeout,omitempty"`
+}
+
+// The timeouts, in seconds, accepted for pausing notifications on a service.
+const (
+	AutoPauseTimeout2Minutes  uint = 120
+	AutoPauseTimeout3Minutes  uint = 180
+	AutoPauseTimeout5Minutes  uint = 300
+	AutoPauseTimeout10Minutes uint = 600
+	AutoPauseTimeout15Minutes uint = 900
+)
+
+func validAutoPauseTimeout(timeout uint) bool {
+	switch timeout {
+	case AutoPauseTimeout2Minutes, AutoPauseTimeout3Minutes, AutoPauseTimeout5Minutes,
+		AutoPauseTimeout10Minutes, AutoPauseTimeout15Minutes:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsAlertGroupingActive reports whether alerts on s are being grouped into
+// incidents. The API's alert_grouping_active flag is used when present;
+// otherwise the answer is derived from the grouping configuration, which
+// requires the service to create alerts and to have a grouping type set.
+func IsAlertGroupingActive(s Service) bool {
+	if s.AlertGroupingActive != nil {
+		return *s.AlertGroupingActive
+	}
+
+	if s.AlertCreation != AlertCreationCreateAlertsAndIncidents {
+		return false
+	}
+
+	if s.AlertGroupingParameters != nil && s.AlertGroupingParameters.Type != "" {
+		return true
+	}
+
+	return s.AlertGrouping != ""
 }
 
 // AlertGroupingParameters defines how alerts on the servicewill be automatically grouped into incidents
@@ -117,11 +410,201 @@ type AlertGroupingParameters struct {
 	Config AlertGroupParamsConfig `json:"config"`
 }
 
-// AlertGroupParamsConfig is the config object on alert_grouping_parameters
+// MarshalJSON satisfies json.Marshaler. Only the config fields that apply to
+// the grouping Type are included, since the API rejects the others.
+func (a AlertGroupingParameters) MarshalJSON() ([]byte, error) {
+	config := a.Config
+
+	switch a.Type {
+	case "time":
+		config = AlertGroupParamsConfig{Timeout: a.Config.Timeout}
+	case "content_based":
+		config = AlertGroupParamsConfig{
+			Aggregate:  a.Config.Aggregate,
+			Fields:     a.Config.Fields,
+			TimeWindow: a.Config.TimeWindow,
+		}
+	case "intelligent":
+		config = AlertGroupParamsConfig{TimeWindow: a.Config.TimeWindow}
+	}
+
+	// RecommendedTimeWindow is computed by PagerDuty and can't be set
+	config.RecommendedTimeWindow = nil
+
+	type alias AlertGroupingParameters
+	return json.Marshal(alias{Type: a.Type, Config: config})
+}
+
+// The values accepted by the Type field of AlertGroupingParameters.
+const (
+	AlertGroupingTypeTime         = "time"
+	AlertGroupingTypeContentBased = "content_based"
+	AlertGroupingTypeIntelligent  = "intelligent"
+)
+
+// validateAlertGroupingParameters checks that the config of p has what its
+// grouping type needs. Content based grouping needs the fields to compare
+// and whether all or any of them must match.
+func validateAlertGroupingParameters(p *AlertGroupingParameters) error {
+	if p == nil {
+		return nil
+	}
+
+	switch p.Type {
+	case AlertGroupingTypeTime, AlertGroupingTypeIntelligent:
+	case AlertGroupingTypeContentBased:
+		if p.Config.Aggregate != "all" && p.Config.Aggregate != "any" {
+			return fmt.Errorf("invalid content based alert grouping aggregate %q, must be %q or %q", p.Config.Aggregate, "all", "any")
+		}
+		if len(p.Config.Fields) == 0 {
+			return fmt.Errorf("content based alert grouping must have at least one field")
+		}
+	case "":
+		return fmt.Errorf("alert grouping parameters must have a type")
+	default:
+		return fmt.Errorf("invalid alert grouping type %q, must be %q, %q or %q", p.Type, AlertGroupingTypeTime, AlertGroupingTypeContentBased, AlertGroupingTypeIntelligent)
+	}
+
+	return nil
+}
+
+// AlertGroupParamsConfig is the config object on alert_grouping_parameters.
+// Which fields apply depends on the grouping type: Timeout is used by time
+// based grouping, Aggregate and Fields by content based grouping, and
+// TimeWindow by both content based and intelligent grouping.
 type AlertGroupParamsConfig struct {
 	Timeout   uint     `json:"timeout,omitempty"`
 	Aggregate string   `json:"aggregate,omitempty"`
 	Fields    []string `json:"fields,omitempty"`
+
+	// TimeWindow is the number of seconds an incident stays open to new
+	// alerts. It's a pointer so that leaving it unset, letting PagerDuty pick
+	// a value, can be told apart from setting it to zero.
+	TimeWindow *uint `json:"time_window,omitempty"`
+
+	// RecommendedTimeWindow is the window PagerDuty recommends for
+	// intelligent grouping, based on the service's alert history. It's read
+	// only and isn't sent back to the API.
+	RecommendedTimeWindow *uint `json:"recommended_time_window,omitempty"`
+}
+
+// UnmarshalJSON satisfies json.Unmarshaler. It sets CreatedAt, the correctly
+// named copy of CreateAt, from the created_at field. CreateAt is kept for
+// compatibility and is the one sent back to the API.
+func (s *Service) UnmarshalJSON(data []byte) error {
+	type alias Service
+	var a alias
+	if err := json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+
+	*s = Service(a)
+	s.CreatedAt = s.CreateAt
+
+	return nil
+}
+
+// CreatedAtTime parses the time the service was created, from CreatedAt or
+// else CreateAt. It returns the zero time if neither is set.
+func (s Service) CreatedAtTime() (time.Time, error) {
+	created := s.CreatedAt
+	if created == "" {
+		created = s.CreateAt
+	}
+	if created == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, created)
+}
+
+// CreatedAtTime parses the time the integration was created. It returns the
+// zero time if CreatedAt isn't set.
+func (i Integration) CreatedAtTime() (time.Time, error) {
+	if i.CreatedAt == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, i.CreatedAt)
+}
+
+// LastIncidentTime parses the service's LastIncidentTimestamp. It returns the
+// zero time if the service has never had an incident.
+func (s Service) LastIncidentTime() (time.Time, error) {
+	if s.LastIncidentTimestamp == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, s.LastIncidentTimestamp)
+}
+
+// The values accepted by the SortBy field of ListServiceOptions. Use
+// ServiceSortBy to combine a field with a sort direction.
+const (
+	ServiceSortByName     = "name"
+	ServiceSortByNameAsc  = "name:asc"
+	ServiceSortByNameDesc = "name:desc"
+)
+
+// The sort directions accepted by ServiceSortBy.
+const (
+	SortDirectionAsc  = "asc"
+	SortDirectionDesc = "desc"
+)
+
+// ServiceSortBy returns the SortBy value of ListServiceOptions sorting the
+// services by field, one of the ServiceSortBy constants without a direction,
+// in the given direction. An empty direction uses the API's default.
+func ServiceSortBy(field, direction string) string {
+	if direction == "" {
+		return field
+	}
+	return field + ":" + direction
+}
+
+// validateServiceSortBy returns an error if sortBy isn't a field and direction
+// the services endpoint can sort by. The API ignores unknown values and falls
+// back to its default order.
+func validateServiceSortBy(sortBy string) error {
+	if sortBy == "" {
+		return nil
+	}
+
+	field, direction := sortBy, ""
+	if i := strings.IndexByte(sortBy, ':'); i >= 0 {
+		field, direction = sortBy[:i], sortBy[i+1:]
+	}
+
+	if field != ServiceSortByName {
+		return fmt.Errorf("unsupported service sort field %q", field)
+	}
+
+	switch direction {
+	case "", SortDirectionAsc, SortDirectionDesc:
+		return nil
+	default:
+		return fmt.Errorf("unsupported sort direction %q", direction)
+	}
+}
+
+// The values accepted by the Includes field of ListServiceOptions and
+// GetServiceOptions.
+const (
+	ServiceIncludeEscalationPolicies               = "escalation_policies"
+	ServiceIncludeTeams                            = "teams"
+	ServiceIncludeIntegrations                     = "integrations"
+	ServiceIncludeAutoPauseNotificationsParameters = "auto_pause_notifications_parameters"
+)
+
+// validateServiceIncludes returns an error for the first value in includes
+// that the services endpoints don't support. The API ignores unknown values,
+// so without this a typo quietly leaves the service without the include.
+func validateServiceIncludes(includes []string) error {
+	for _, inc := range includes {
+		switch inc {
+		case ServiceIncludeEscalationPolicies, ServiceIncludeTeams, ServiceIncludeIntegrations, ServiceIncludeAutoPauseNotificationsParameters:
+		default:
+			return fmt.Errorf("unsupported service include %q", inc)
+		}
+	}
+	return nil
 }
 
 // ListServiceOptions is the data structure used when calling the ListServices API endpoint.
@@ -132,6 +615,17 @@ type ListServiceOptions struct {
 	SortBy   string   `url:"sort_by,omitempty"`
 	Query    string   `url:"query,omitempty"`
 	Includes []string `url:"include,omitempty,brackets"`
+
+	// Prefetch makes ListServicesPaginated and ListServicesPaginatedWithFunc
+	// fetch the next page while the services of the current one are being
+	// processed. It isn't sent to the API.
+	Prefetch bool `url:"-"`
+
+	// ListAll makes ListServices fetch every page and return them as a single
+	// response, like ListServicesPaginated. Limit is then the size of each
+	// page fetched, Offset is ignored, and the response's More is false. It
+	// isn't sent to the API.
+	ListAll bool `url:"-"`
 }
 
 // ListServiceResponse is the data structure returned from calling the ListServices API endpoint.
@@ -142,11 +636,29 @@ type ListServiceResponse struct {
 
 // ListServices lists existing services.
 func (c *Client) ListServices(o ListServiceOptions) (*ListServiceResponse, error) {
+	return c.ListServicesWithContext(context.Background(), o)
+}
+
+// ListServicesWithContext lists existing services.
+func (c *Client) ListServicesWithContext(ctx context.Context, o ListServiceOptions) (*ListServiceResponse, error) {
+	if err := validateServiceIncludes(o.Includes); err != nil {
+		return nil, err
+	}
+	if err := validateServiceSortBy(o.SortBy); err != nil {
+		return nil, err
+	}
+	if o.ListAll {
+		services, err := c.ListServicesPaginated(ctx, o)
+		if err != nil {
+			return nil, err
+		}
+		return &ListServiceResponse{Services: services}, nil
+	}
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.get(context.TODO(), "/services?"+v.Encode())
+	resp, err := c.get(ctx, "/services?"+v.Encode())
 	if err != nil {
 		return nil, err
 	}
@@ -154,33 +666,164 @@ func (c *Client) ListServices(o ListServiceOptions) (*ListServiceResponse, error
 	return &result, c.decodeJSON(resp, &result)
 }
 
-// ListServices lists existing services processing paginated responses
+// ListServicesPaginated lists existing services processing paginated
+// responses. If a page fails the services of the pages before it are
+// returned along with a PageError describing the page that failed.
 func (c *Client) ListServicesPaginated(ctx context.Context, o ListServiceOptions) ([]Service, error) {
 	var services []Service
+
+	err := c.ListServicesPaginatedWithFunc(ctx, o, func(s Service) error {
+		services = append(services, s)
+		return nil
+	})
+
+	return services, err
+}
+
+// ListServicesPaginatedWithFunc lists existing services, calling f with each
+// service as its page arrives rather than collecting them all in memory. If f
+// returns an error no further pages are fetched, and that error is returned.
+// The pages hold o.Limit services, or the API's default when it's zero; the
+// other APIListObject fields of o are ignored. Set o.Prefetch to fetch each
+// page while f is called with the services of the previous one.
+func (c *Client) ListServicesPaginatedWithFunc(ctx context.Context, o ListServiceOptions, f func(Service) error) error {
+	if err := validateServiceIncludes(o.Includes); err != nil {
+		return err
+	}
+	if err := validateServiceSortBy(o.SortBy); err != nil {
+		return err
+	}
+
+	// only the page size is sent, the offset is set on each page
+	o.APIListObject = APIListObject{Limit: o.Limit}
+
 	v, err := query.Values(o)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	responseHandler := func(response *http.Response) (APIListObject, error) {
+
+	decode := func(response *http.Response) (APIListObject, func() error, error) {
 		var result ListServiceResponse
 		if err := c.decodeJSON(response, &result); err != nil {
-			return APIListObject{}, err
+			return APIListObject{}, nil, err
 		}
 
-		services = append(services, result.Services...)
+		process := func() error {
+			for _, s := range result.Services {
+				if err := f(s); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
 
 		return APIListObject{
 			More:   result.More,
 			Offset: result.Offset,
 			Limit:  result.Limit,
-		}, nil
+		}, process, nil
 	}
-	if err := c.pagedGet(ctx, "/services?"+v.Encode(), responseHandler); err != nil {
+
+	if o.Prefetch {
+		return c.pagedGetPrefetch(ctx, "/services?"+v.Encode(), decode)
+	}
+
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		pageInfo, process, err := decode(response)
+		if err != nil {
+			return APIListObject{}, err
+		}
+		if err := process(); err != nil {
+			return APIListObject{}, err
+		}
+		return pageInfo, nil
+	}
+	return c.pagedGet(ctx, "/services?"+v.Encode(), responseHandler)
+}
+
+// ListServicesByLastIncident lists all services matching o, ordered by when
+// each last had an incident, most recent first. Services that have never had
+// an incident come last. The API can only sort services by name, so every
+// page is fetched and the services are sorted client-side; o.SortBy is
+// ignored.
+func (c *Client) ListServicesByLastIncident(ctx context.Context, o ListServiceOptions) ([]Service, error) {
+	o.SortBy = ""
+
+	services, err := c.ListServicesPaginated(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+
+	last := make(map[string]time.Time, len(services))
+	for _, s := range services {
+		t, err := s.LastIncidentTime()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse last incident timestamp of service %s: %w", s.ID, err)
+		}
+		last[s.ID] = t
+	}
+
+	sort.SliceStable(services, func(i, j int) bool {
+		return last[services[i].ID].After(last[services[j].ID])
+	})
+
+	return services, nil
+}
+
+// ListServicesByEscalationPolicy lists the services using the escalation
+// policy escalationPolicyID. The list endpoint can't filter by escalation
+// policy, so every service is listed and the matching ones are kept.
+func (c *Client) ListServicesByEscalationPolicy(ctx context.Context, escalationPolicyID string) ([]Service, error) {
+	services := make([]Service, 0)
+
+	err := c.ListServicesPaginatedWithFunc(ctx, ListServiceOptions{}, func(s Service) error {
+		if s.EscalationPolicy.ID == escalationPolicyID {
+			services = append(services, s)
+		}
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
+
 	return services, nil
 }
 
+// errServiceFound stops the listing of FindServiceByIntegrationKey once the
+// service is found.
+var errServiceFound = errors.New("service found")
+
+// FindServiceByIntegrationKey finds the service owning the integration with
+// the routing key key, and returns it along with the integration. The list
+// endpoint can't filter by key, so the services are listed with their
+// integrations until one matches.
+func (c *Client) FindServiceByIntegrationKey(ctx context.Context, key string) (*Service, *Integration, error) {
+	var (
+		service     *Service
+		integration *Integration
+	)
+
+	o := ListServiceOptions{Includes: []string{ServiceIncludeIntegrations}}
+
+	err := c.ListServicesPaginatedWithFunc(ctx, o, func(s Service) error {
+		for i := range s.Integrations {
+			if s.Integrations[i].IntegrationKey == key {
+				service, integration = &s, &s.Integrations[i]
+				return errServiceFound
+			}
+		}
+		return nil
+	})
+	if service != nil {
+		return service, integration, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return nil, nil, fmt.Errorf("no service has an integration with key %s", key)
+}
+
 // GetServiceOptions is the data structure used when calling the GetService API endpoint.
 type GetServiceOptions struct {
 	Includes []string `url:"include,brackets,omitempty"`
@@ -188,44 +831,262 @@ type GetServiceOptions struct {
 
 // GetService gets details about an existing service.
 func (c *Client) GetService(id string, o *GetServiceOptions) (*Service, error) {
+	return c.GetServiceWithContext(context.Background(), id, o)
+}
+
+// GetServiceWithContext gets details about an existing service.
+func (c *Client) GetServiceWithContext(ctx context.Context, id string, o *GetServiceOptions) (*Service, error) {
+	s, _, err := c.GetServiceWithResponse(ctx, id, o)
+	return s, err
+}
+
+// GetServiceWithResponse is like GetServiceWithContext, but also returns the
+// HTTP response so that headers like X-Request-Id can be inspected. The
+// response is returned for API errors too, but its body has been consumed.
+func (c *Client) GetServiceWithResponse(ctx context.Context, id string, o *GetServiceOptions) (*Service, *http.Response, error) {
+	if o != nil {
+		if err := validateServiceIncludes(o.Includes); err != nil {
+			return nil, nil, err
+		}
+	}
 	v, err := query.Values(o)
-	resp, err := c.get(context.TODO(), "/services/"+id+"?"+v.Encode())
-	return getServiceFromResponse(c, resp, err)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := c.get(ctx, "/services/"+id+"?"+v.Encode())
+	s, err := getServiceFromResponse(c, resp, err)
+	return s, resp, err
+}
+
+// GetServicesByIDs gets several services concurrently. The returned slice
+// lines up with ids; if some services can't be fetched their positions hold
+// the zero Service and the error is a *BatchError keyed by the same index.
+// Use WithBatchConcurrency to control how many requests are made at once.
+func (c *Client) GetServicesByIDs(ctx context.Context, ids []string, o *GetServiceOptions) ([]Service, error) {
+	services := make([]Service, len(ids))
+
+	err := c.runBatch(ctx, len(ids), func(ctx context.Context, i int) error {
+		s, err := c.GetServiceWithContext(ctx, ids[i], o)
+		if err != nil {
+			return err
+		}
+		services[i] = *s
+		return nil
+	})
+
+	return services, err
+}
+
+// ValidateService checks s locally, without calling the API, for the
+// mistakes CreateServiceWithContext would otherwise only learn about from a
+// 400 response or, for settings the API accepts silently, never: a missing
+// name or escalation policy, an invalid status or alert creation, alert
+// grouping parameters that don't match their type, and incomplete support
+// hours or scheduled actions.
+func ValidateService(s Service) error {
+	if s.Name == "" {
+		return fmt.Errorf("service must have a name")
+	}
+
+	if s.EscalationPolicy.ID == "" {
+		return fmt.Errorf("service must have an escalation policy")
+	}
+
+	if err := validateServiceSettings(s); err != nil {
+		return err
+	}
+
+	// the checks below depend on several fields being set together, which an
+	// update may leave out when the service already has them
+	if err := validateAlertGroupingParameters(s.AlertGroupingParameters); err != nil {
+		return err
+	}
+
+	if s.IncidentUrgencyRule != nil && s.IncidentUrgencyRule.Type == "use_support_hours" && s.SupportHours == nil {
+		return fmt.Errorf("an incident urgency rule using support hours needs the service to have support hours")
+	}
+
+	if len(s.ScheduledActions) > 0 && s.SupportHours == nil {
+		return fmt.Errorf("scheduled actions need the service to have support hours")
+	}
+
+	return nil
+}
+
+// validateServiceSettings runs the checks of ValidateService that also apply
+// to updates, which may leave out the name and escalation policy, and only
+// send some of the settings.
+func validateServiceSettings(s Service) error {
+	if s.Status != "" && !ValidServiceStatus(s.Status) {
+		return fmt.Errorf("invalid service status %q", s.Status)
+	}
+
+	if s.AlertCreation != "" && !ValidAlertCreation(s.AlertCreation) {
+		return fmt.Errorf("invalid alert creation %q, must be %q or %q", s.AlertCreation, AlertCreationCreateIncidents, AlertCreationCreateAlertsAndIncidents)
+	}
+
+	if err := validateSupportHours(s.SupportHours); err != nil {
+		return err
+	}
+
+	for i, a := range s.ScheduledActions {
+		if err := validateScheduledAction(a); err != nil {
+			return fmt.Errorf("scheduled action %d: %w", i, err)
+		}
+	}
+
+	return nil
 }
 
 // CreateService creates a new service.
 func (c *Client) CreateService(s Service) (*Service, error) {
-	data := make(map[string]Service)
-	data["service"] = s
-	resp, err := c.post(context.TODO(), "/services", data, nil)
-	return getServiceFromResponse(c, resp, err)
+	return c.CreateServiceWithContext(context.Background(), s)
+}
+
+// CreateServiceWithContext creates a new service, after checking it with
+// ValidateService.
+func (c *Client) CreateServiceWithContext(ctx context.Context, s Service, opts ...RequestOption) (*Service, error) {
+	svc, _, err := c.CreateServiceWithResponse(ctx, s, opts...)
+	return svc, err
+}
+
+// CreateServiceWithResponse is like CreateServiceWithContext, but also
+// returns the HTTP response. It is nil if the service failed validation
+// before being sent.
+func (c *Client) CreateServiceWithResponse(ctx context.Context, s Service, opts ...RequestOption) (*Service, *http.Response, error) {
+	if err := ValidateService(s); err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := c.post(ctx, "/services", wrapBody("service", s), requestHeaders(opts))
+	svc, err := getServiceFromResponse(c, resp, err)
+	return svc, resp, err
 }
 
 // UpdateService updates an existing service.
 func (c *Client) UpdateService(s Service) (*Service, error) {
-	body := struct {
-		Service `json:"service,omitempty"`
-	}{
-		s,
+	return c.UpdateServiceWithContext(context.Background(), s)
+}
+
+// UpdateServiceWithContext updates an existing service. The checks of
+// ValidateService that only look at the fields being sent are run; the name
+// and escalation policy, and the checks relating settings to each other, such
+// as scheduled actions needing support hours, are left to the API, since the
+// service may already have the settings s leaves out.
+func (c *Client) UpdateServiceWithContext(ctx context.Context, s Service) (*Service, error) {
+	svc, _, err := c.UpdateServiceWithResponse(ctx, s)
+	return svc, err
+}
+
+// UpdateServiceWithResponse is like UpdateServiceWithContext, but also
+// returns the HTTP response. It is nil if the service failed validation
+// before being sent.
+func (c *Client) UpdateServiceWithResponse(ctx context.Context, s Service) (*Service, *http.Response, error) {
+	if err := validateServiceSettings(s); err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := c.put(ctx, "/services/"+s.ID, wrapBody("service", s), nil)
+	svc, err := getServiceFromResponse(c, resp, err)
+	return svc, resp, err
+}
+
+// UpdateServiceFields updates only the given fields of an existing service,
+// keyed by their JSON name, such as "auto_resolve_timeout". UpdateService
+// sends every set field of a Service, which can overwrite a change made
+// elsewhere in between; sending just the fields being changed avoids that,
+// and a nil value clears a field, such as a null auto_resolve_timeout turning
+// auto resolution off.
+func (c *Client) UpdateServiceFields(ctx context.Context, id string, fields map[string]interface{}) (*Service, error) {
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields to update on service %s", id)
 	}
-	resp, err := c.put(context.TODO(), "/services/"+s.ID, body, nil)
+
+	resp, err := c.put(ctx, "/services/"+id, wrapBody("service", fields), nil)
+	return getServiceFromResponse(c, resp, err)
+}
+
+// SetAutoPause enables or disables auto-pausing of notifications for
+// transient alerts on a service. When enabling it, timeout must be one of the
+// AutoPauseTimeout constants; it is ignored when disabling. No other fields of
+// the service are modified.
+func (c *Client) SetAutoPause(ctx context.Context, serviceID string, enabled bool, timeout uint) (*Service, error) {
+	p := AutoPauseNotificationsParameters{Enabled: enabled}
+
+	if enabled {
+		if !validAutoPauseTimeout(timeout) {
+			return nil, fmt.Errorf("auto-pause timeout %d is not one of the allowed values (120, 180, 300, 600, 900)", timeout)
+		}
+		p.Timeout = timeout
+	}
+
+	d := map[string]interface{}{
+		"auto_pause_notifications_parameters": p,
+	}
+
+	resp, err := c.put(ctx, "/services/"+serviceID, wrapBody("service", d), nil)
 	return getServiceFromResponse(c, resp, err)
 }
 
 // DeleteService deletes an existing service.
 func (c *Client) DeleteService(id string) error {
-	_, err := c.delete(context.TODO(), "/services/"+id)
+	return c.DeleteServiceWithContext(context.Background(), id)
+}
+
+// DeleteServiceWithContext deletes an existing service.
+func (c *Client) DeleteServiceWithContext(ctx context.Context, id string) error {
+	_, err := c.delete(ctx, "/services/"+id)
 	return err
 }
 
 // CreateIntegration creates a new integration belonging to a service.
 func (c *Client) CreateIntegration(id string, i Integration) (*Integration, error) {
-	data := make(map[string]Integration)
-	data["integration"] = i
-	resp, err := c.post(context.TODO(), "/services/"+id+"/integrations", data, nil)
+	return c.CreateIntegrationWithContext(context.Background(), id, i)
+}
+
+// CreateIntegrationWithContext creates a new integration belonging to a
+// service.
+func (c *Client) CreateIntegrationWithContext(ctx context.Context, id string, i Integration, opts ...RequestOption) (*Integration, error) {
+	resp, err := c.post(ctx, "/services/"+id+"/integrations", wrapBody("integration", i), requestHeaders(opts))
 	return getIntegrationFromResponse(c, resp, err)
 }
 
+// CreateIntegrations creates several integrations on a service concurrently.
+// Every integration is attempted even if some fail. The returned slice lines
+// up with integrations, holding nil for each one that could not be created;
+// in that case the error is a *BatchError keyed by the same index.
+func (c *Client) CreateIntegrations(ctx context.Context, serviceID string, integrations []Integration) ([]*Integration, error) {
+	created := make([]*Integration, len(integrations))
+
+	err := c.runBatch(ctx, len(integrations), func(ctx context.Context, i int) error {
+		in, err := c.CreateIntegrationWithContext(ctx, serviceID, integrations[i])
+		if err != nil {
+			return err
+		}
+		created[i] = in
+		return nil
+	})
+
+	return created, err
+}
+
+// ListIntegrations lists the integrations belonging to a service. The API
+// has no listing endpoint for integrations, so this fetches the service with
+// its integrations included. A service without integrations yields an empty
+// slice.
+func (c *Client) ListIntegrations(ctx context.Context, serviceID string) ([]Integration, error) {
+	s, err := c.GetServiceWithContext(ctx, serviceID, &GetServiceOptions{Includes: []string{ServiceIncludeIntegrations}})
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Integrations == nil {
+		return []Integration{}, nil
+	}
+
+	return s.Integrations, nil
+}
+
 // GetIntegrationOptions is the data structure used when calling the GetIntegration API endpoint.
 type GetIntegrationOptions struct {
 	Includes []string `url:"include,omitempty,brackets"`
@@ -233,28 +1094,99 @@ type GetIntegrationOptions struct {
 
 // GetIntegration gets details about an integration belonging to a service.
 func (c *Client) GetIntegration(serviceID, integrationID string, o GetIntegrationOptions) (*Integration, error) {
+	return c.GetIntegrationWithContext(context.Background(), serviceID, integrationID, o)
+}
+
+// GetIntegrationWithContext gets details about an integration belonging to a service.
+func (c *Client) GetIntegrationWithContext(ctx context.Context, serviceID, integrationID string, o GetIntegrationOptions) (*Integration, error) {
 	v, queryErr := query.Values(o)
 	if queryErr != nil {
 		return nil, queryErr
 	}
-	resp, err := c.get(context.TODO(), "/services/"+serviceID+"/integrations/"+integrationID+"?"+v.Encode())
+	resp, err := c.get(ctx, "/services/"+serviceID+"/integrations/"+integrationID+"?"+v.Encode())
 	return getIntegrationFromResponse(c, resp, err)
 }
 
 // UpdateIntegration updates an integration belonging to a service.
 func (c *Client) UpdateIntegration(serviceID string, i Integration) (*Integration, error) {
-	resp, err := c.put(context.TODO(), "/services/"+serviceID+"/integrations/"+i.ID, i, nil)
+	return c.UpdateIntegrationWithContext(context.Background(), serviceID, i)
+}
+
+// UpdateIntegrationWithContext updates an integration belonging to a service.
+func (c *Client) UpdateIntegrationWithContext(ctx context.Context, serviceID string, i Integration) (*Integration, error) {
+	resp, err := c.put(ctx, "/services/"+serviceID+"/integrations/"+i.ID, wrapBody("integration", i), nil)
+	return getIntegrationFromResponse(c, resp, err)
+}
+
+// SetIntegrationEnabled enables or disables an integration belonging to a
+// service. Only the enabled flag is sent, so the integration's other fields
+// are left as they are.
+func (c *Client) SetIntegrationEnabled(ctx context.Context, serviceID, integrationID string, enabled bool) (*Integration, error) {
+	d := map[string]bool{
+		"enabled": enabled,
+	}
+
+	resp, err := c.put(ctx, "/services/"+serviceID+"/integrations/"+integrationID, wrapBody("integration", d), nil)
 	return getIntegrationFromResponse(c, resp, err)
 }
 
+// RegenerateIntegrationKey replaces an integration with an identical one that
+// has a new integration key. The API can't change the key of an existing
+// integration, so this creates a copy of it, with all of its settings, and
+// then deletes the original; note that the returned integration therefore
+// has a new ID. If the original can't be deleted the new integration is still
+// returned, along with the error.
+func (c *Client) RegenerateIntegrationKey(ctx context.Context, serviceID, integrationID string) (*Integration, error) {
+	old, err := c.GetIntegrationWithContext(ctx, serviceID, integrationID, GetIntegrationOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// only the fields the API sets itself are left out of the copy
+	i := *old
+	i.ID = ""
+	i.Self = ""
+	i.HTMLURL = ""
+	i.IntegrationKey = ""
+	i.CreatedAt = ""
+
+	created, err := c.CreateIntegrationWithContext(ctx, serviceID, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// the create response doesn't always echo the vendor back
+	if created.Vendor == nil {
+		created.Vendor = old.Vendor
+	}
+
+	if err := c.DeleteIntegrationWithContext(ctx, serviceID, integrationID); err != nil {
+		return created, fmt.Errorf("created integration %s but failed to delete integration %s: %w", created.ID, integrationID, err)
+	}
+
+	return created, nil
+}
+
 // DeleteIntegration deletes an existing integration.
 func (c *Client) DeleteIntegration(serviceID string, integrationID string) error {
-	_, err := c.delete(context.TODO(), "/services/"+serviceID+"/integrations/"+integrationID)
+	return c.DeleteIntegrationWithContext(context.Background(), serviceID, integrationID)
+}
+
+// DeleteIntegrationWithContext deletes an existing integration.
+func (c *Client) DeleteIntegrationWithContext(ctx context.Context, serviceID string, integrationID string) error {
+	_, err := c.delete(ctx, "/services/"+serviceID+"/integrations/"+integrationID)
 	return err
 }
 
 // ListServiceRules gets all rules for a service.
 func (c *Client) ListServiceRules(serviceID string) (*ListServiceRulesResponse, error) {
+	return c.ListServiceRulesWithContext(context.Background(), serviceID)
+}
+
+// ListServiceRulesWithContext gets all rules for a service. If a page fails
+// the rules of the pages before it are returned along with a PageError
+// describing the page that failed.
+func (c *Client) ListServiceRulesWithContext(ctx context.Context, serviceID string) (*ListServiceRulesResponse, error) {
 	rulesResponse := new(ListServiceRulesResponse)
 	rules := make([]*ServiceRule, 0)
 
@@ -279,39 +1211,195 @@ func (c *Client) ListServiceRules(serviceID string) (*ListServiceRulesResponse,
 	}
 
 	// Make call to get all pages associated with the base endpoint.
-	if err := c.pagedGet(context.TODO(), "/services/"+serviceID+"/rules", responseHandler); err != nil {
+	err := c.pagedGet(ctx, "/services/"+serviceID+"/rules", responseHandler)
+	rulesResponse.Rules = rules
+
+	return rulesResponse, err
+}
+
+// ListServiceRulesPaginated gets the single page of rules for a service
+// described by o.Offset and o.Limit. The response always includes the total
+// number of rules on the service.
+func (c *Client) ListServiceRulesPaginated(ctx context.Context, serviceID string, o APIListObject) (*ListServiceRulesResponse, error) {
+	v := url.Values{}
+	v.Set("total", "true")
+	if o.Limit > 0 {
+		v.Set("limit", strconv.FormatUint(uint64(o.Limit), 10))
+	}
+	if o.Offset > 0 {
+		v.Set("offset", strconv.FormatUint(uint64(o.Offset), 10))
+	}
+
+	resp, err := c.get(ctx, "/services/"+serviceID+"/rules?"+v.Encode())
+	if err != nil {
 		return nil, err
 	}
-	rulesResponse.Rules = rules
 
-	return rulesResponse, nil
+	var result ListServiceRulesResponse
+	if err := c.decodeJSON(resp, &result); err != nil {
+		return nil, fmt.Errorf("Could not decode JSON response: %w", err)
+	}
+
+	return &result, nil
 }
 
 // GetServiceRule gets a service rule.
 func (c *Client) GetServiceRule(serviceID, ruleID string) (*ServiceRule, *http.Response, error) {
-	resp, err := c.get(context.TODO(), "/services/"+serviceID+"/rules/"+ruleID)
+	return c.GetServiceRuleWithContext(context.Background(), serviceID, ruleID)
+}
+
+// GetServiceRuleWithContext gets a service rule.
+func (c *Client) GetServiceRuleWithContext(ctx context.Context, serviceID, ruleID string) (*ServiceRule, *http.Response, error) {
+	resp, err := c.get(ctx, "/services/"+serviceID+"/rules/"+ruleID)
 	return getServiceRuleFromResponse(c, resp, err)
 }
 
 // DeleteServiceRule deletes a service rule.
 func (c *Client) DeleteServiceRule(serviceID, ruleID string) error {
-	_, err := c.delete(context.TODO(), "/services/"+serviceID+"/rules/"+ruleID)
+	return c.DeleteServiceRuleWithContext(context.Background(), serviceID, ruleID)
+}
+
+// DeleteServiceRuleWithContext deletes a service rule.
+func (c *Client) DeleteServiceRuleWithContext(ctx context.Context, serviceID, ruleID string) error {
+	_, err := c.delete(ctx, "/services/"+serviceID+"/rules/"+ruleID)
 	return err
 }
 
 // CreateServiceRule creates a service rule.
 func (c *Client) CreateServiceRule(serviceID string, rule *ServiceRule) (*ServiceRule, *http.Response, error) {
-	data := make(map[string]*ServiceRule)
-	data["rule"] = rule
-	resp, err := c.post(context.TODO(), "/services/"+serviceID+"/rules/", data, nil)
+	return c.CreateServiceRuleWithContext(context.Background(), serviceID, rule)
+}
+
+// CreateServiceRuleWithContext creates a service rule.
+func (c *Client) CreateServiceRuleWithContext(ctx context.Context, serviceID string, rule *ServiceRule) (*ServiceRule, *http.Response, error) {
+	resp, err := c.post(ctx, "/services/"+serviceID+"/rules/", wrapBody("rule", rule), nil)
 	return getServiceRuleFromResponse(c, resp, err)
 }
 
+// CopyServiceRules recreates the rules of one service on another, in the same
+// order, and returns the rules that were created. The copies are created
+// after any rules the destination already has. Rules are created one at a
+// time to keep their order; if one fails, the rules created before it are
+// returned along with the error.
+func (c *Client) CopyServiceRules(ctx context.Context, sourceServiceID, destServiceID string) ([]*ServiceRule, error) {
+	src, err := c.ListServiceRulesWithContext(ctx, sourceServiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]*ServiceRule, len(src.Rules))
+	copy(rules, src.Rules)
+
+	// the API lists rules in order, but be defensive about it when every rule
+	// reports its position
+	positioned := true
+	for _, r := range rules {
+		if r.Position == nil {
+			positioned = false
+			break
+		}
+	}
+
+	if positioned {
+		sort.SliceStable(rules, func(i, j int) bool {
+			return *rules[i].Position < *rules[j].Position
+		})
+	}
+
+	created := make([]*ServiceRule, 0, len(rules))
+
+	for i, r := range rules {
+		rule := *r
+		rule.ID = ""
+		rule.Self = ""
+		// leave the position unset so each copy is appended after the last
+		rule.Position = nil
+
+		cr, _, err := c.CreateServiceRuleWithContext(ctx, destServiceID, &rule)
+		if err != nil {
+			return created, fmt.Errorf("failed to copy rule %d of %d (%s): %w", i+1, len(rules), r.ID, err)
+		}
+
+		created = append(created, cr)
+	}
+
+	return created, nil
+}
+
+// ReorderServiceRules moves the rules of a service into the order given by
+// orderedRuleIDs, which must list every rule of the service exactly once. The
+// API has no bulk reorder endpoint, so each rule is updated in turn; if one of
+// the updates fails, the rules are put back in their original order before
+// the error is returned. The rollback still runs if ctx has been canceled.
+func (c *Client) ReorderServiceRules(ctx context.Context, serviceID string, orderedRuleIDs []string) error {
+	current, err := c.ListServiceRulesWithContext(ctx, serviceID)
+	if err != nil {
+		return err
+	}
+
+	if len(orderedRuleIDs) != len(current.Rules) {
+		return fmt.Errorf("service %s has %d rules, but %d rule IDs were given", serviceID, len(current.Rules), len(orderedRuleIDs))
+	}
+
+	rules := make(map[string]*ServiceRule, len(current.Rules))
+	originalIDs := make([]string, len(current.Rules))
+	for i, r := range current.Rules {
+		rules[r.ID] = r
+		originalIDs[i] = r.ID
+	}
+
+	seen := make(map[string]bool, len(orderedRuleIDs))
+	for _, id := range orderedRuleIDs {
+		if _, ok := rules[id]; !ok {
+			return fmt.Errorf("rule %s does not belong to service %s", id, serviceID)
+		}
+		if seen[id] {
+			return fmt.Errorf("rule %s is listed more than once", id)
+		}
+		seen[id] = true
+	}
+
+	if err := c.applyServiceRuleOrder(ctx, serviceID, rules, orderedRuleIDs); err != nil {
+		rollbackCtx := ctx
+		if ctx.Err() != nil {
+			rollbackCtx = context.Background()
+		}
+
+		if rbErr := c.applyServiceRuleOrder(rollbackCtx, serviceID, rules, originalIDs); rbErr != nil {
+			return fmt.Errorf("failed to reorder service rules: %w (restoring the original order also failed: %v)", err, rbErr)
+		}
+
+		return fmt.Errorf("failed to reorder service rules, the original order was restored: %w", err)
+	}
+
+	return nil
+}
+
+// applyServiceRuleOrder moves each rule in ids to its index in ids. Placing
+// the rules front to back means the ones already placed are never shifted by
+// later moves.
+func (c *Client) applyServiceRuleOrder(ctx context.Context, serviceID string, rules map[string]*ServiceRule, ids []string) error {
+	for i, id := range ids {
+		rule := *rules[id]
+		pos := i
+		rule.Position = &pos
+
+		if _, _, err := c.UpdateServiceRuleWithContext(ctx, serviceID, id, &rule); err != nil {
+			return fmt.Errorf("failed to move rule %s to position %d: %w", id, i, err)
+		}
+	}
+
+	return nil
+}
+
 // UpdateServiceRule updates a service rule.
 func (c *Client) UpdateServiceRule(serviceID, ruleID string, rule *ServiceRule) (*ServiceRule, *http.Response, error) {
-	data := make(map[string]*ServiceRule)
-	data["rule"] = rule
-	resp, err := c.put(context.TODO(), "/services/"+serviceID+"/rules/"+ruleID, data, nil)
+	return c.UpdateServiceRuleWithContext(context.Background(), serviceID, ruleID, rule)
+}
+
+// UpdateServiceRuleWithContext updates a service rule.
+func (c *Client) UpdateServiceRuleWithContext(ctx context.Context, serviceID, ruleID string, rule *ServiceRule) (*ServiceRule, *http.Response, error) {
+	resp, err := c.put(ctx, "/services/"+serviceID+"/rules/"+ruleID, wrapBody("rule", rule), nil)
 	return getServiceRuleFromResponse(c, resp, err)
 }
 
@@ -321,7 +1409,7 @@ func getServiceRuleFromResponse(c *Client, resp *http.Response, err error) (*Ser
 	}
 	var target map[string]ServiceRule
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return nil, nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
+		return nil, nil, fmt.Errorf("Could not decode JSON response: %w", dErr)
 	}
 	rootNode := "rule"
 	t, nodeOK := target[rootNode]
@@ -333,13 +1421,11 @@ func getServiceRuleFromResponse(c *Client, resp *http.Response, err error) (*Ser
 
 func getServiceFromResponse(c *Client, resp *http.Response, err error) (*Service, error) {
 	if err != nil {
-		body, _ := ioutil.ReadAll(resp.Body)
-		log.WithFields(log.Fields{"status": resp.Status, "body": body, "error": err}).Info("Error on the service request")
 		return nil, err
 	}
 	var target map[string]Service
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
+		return nil, fmt.Errorf("Could not decode JSON response: %w", dErr)
 	}
 	rootNode := "service"
 	t, nodeOK := target[rootNode]
@@ -355,7 +1441,7 @@ func getIntegrationFromResponse(c *Client, resp *http.Response, err error) (*Int
 	}
 	var target map[string]Integration
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return nil, fmt.Errorf("Could not decode JSON response: %v", err)
+		return nil, fmt.Errorf("Could not decode JSON response: %w", dErr)
 	}
 	rootNode := "integration"
 	t, nodeOK := target[rootNode]
@@ -26,7 +26,12 @@ type ListServiceDependencies struct {
 
 // ListBusinessServiceDependencies lists dependencies of a business service.
 func (c *Client) ListBusinessServiceDependencies(businessServiceID string) (*ListServiceDependencies, *http.Response, error) {
-	resp, err := c.get(context.TODO(), "/service_dependencies/business_services/"+businessServiceID)
+	return c.ListBusinessServiceDependenciesWithContext(context.TODO(), businessServiceID)
+}
+
+// ListBusinessServiceDependenciesWithContext lists dependencies of a business service.
+func (c *Client) ListBusinessServiceDependenciesWithContext(ctx context.Context, businessServiceID string) (*ListServiceDependencies, *http.Response, error) {
+	resp, err := c.get(ctx, "/service_dependencies/business_services/"+businessServiceID)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -36,7 +41,12 @@ func (c *Client) ListBusinessServiceDependencies(businessServiceID string) (*Lis
 
 // ListTechnicalServiceDependencies lists dependencies of a technical service.
 func (c *Client) ListTechnicalServiceDependencies(serviceID string) (*ListServiceDependencies, *http.Response, error) {
-	resp, err := c.get(context.TODO(), "/service_dependencies/technical_services/"+serviceID)
+	return c.ListTechnicalServiceDependenciesWithContext(context.TODO(), serviceID)
+}
+
+// ListTechnicalServiceDependenciesWithContext lists dependencies of a technical service.
+func (c *Client) ListTechnicalServiceDependenciesWithContext(ctx context.Context, serviceID string) (*ListServiceDependencies, *http.Response, error) {
+	resp, err := c.get(ctx, "/service_dependencies/technical_services/"+serviceID)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -44,9 +54,25 @@ func (c *Client) ListTechnicalServiceDependencies(serviceID string) (*ListServic
 	return &result, resp, c.decodeJSON(resp, &result)
 }
 
+// ListServiceDependenciesForService lists the relationships a technical
+// service is part of, both the services it supports and the services it
+// depends on.
+func (c *Client) ListServiceDependenciesForService(ctx context.Context, serviceID string) ([]*ServiceDependency, error) {
+	result, _, err := c.ListTechnicalServiceDependenciesWithContext(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	return result.Relationships, nil
+}
+
 // AssociateServiceDependencies Create new dependencies between two services.
 func (c *Client) AssociateServiceDependencies(dependencies *ListServiceDependencies) (*ListServiceDependencies, *http.Response, error) {
-	resp, err := c.post(context.TODO(), "/service_dependencies/associate", dependencies, nil)
+	return c.AssociateServiceDependenciesWithContext(context.TODO(), dependencies)
+}
+
+// AssociateServiceDependenciesWithContext Create new dependencies between two services.
+func (c *Client) AssociateServiceDependenciesWithContext(ctx context.Context, dependencies *ListServiceDependencies) (*ListServiceDependencies, *http.Response, error) {
+	resp, err := c.post(ctx, "/service_dependencies/associate", dependencies, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -56,7 +82,12 @@ func (c *Client) AssociateServiceDependencies(dependencies *ListServiceDependenc
 
 // DisassociateServiceDependencies Disassociate dependencies between two services.
 func (c *Client) DisassociateServiceDependencies(dependencies *ListServiceDependencies) (*ListServiceDependencies, *http.Response, error) {
-	resp, err := c.post(context.TODO(), "/service_dependencies/disassociate", dependencies, nil)
+	return c.DisassociateServiceDependenciesWithContext(context.TODO(), dependencies)
+}
+
+// DisassociateServiceDependenciesWithContext Disassociate dependencies between two services.
+func (c *Client) DisassociateServiceDependenciesWithContext(ctx context.Context, dependencies *ListServiceDependencies) (*ListServiceDependencies, *http.Response, error) {
+	resp, err := c.post(ctx, "/service_dependencies/disassociate", dependencies, nil)
 	if err != nil {
 		return nil, nil, err
 	}
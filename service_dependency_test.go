@@ -1,6 +1,7 @@
 package pagerduty
 
 import (
+	"context"
 	"net/http"
 	"testing"
 )
@@ -166,3 +167,31 @@ func TestServiceDependency_Disassociate(t *testing.T) {
 	}
 	testEqual(t, want, res)
 }
+
+// List Service Dependencies For Service
+func TestServiceDependency_ListForService(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/service_dependencies/technical_services/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Write([]byte(`{"relationships": [{"id": "D1","dependent_service":{"id":"1","type":"service"},"supporting_service":{"id":"2","type":"service"},"type":"service_dependency"}]}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+	res, err := client.ListServiceDependenciesForService(context.Background(), "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*ServiceDependency{
+		{
+			ID:                "D1",
+			Type:              "service_dependency",
+			DependentService:  &ServiceObj{ID: "1", Type: "service"},
+			SupportingService: &ServiceObj{ID: "2", Type: "service"},
+		},
+	}
+
+	testEqual(t, want, res)
+}
@@ -2,10 +2,15 @@ package pagerduty
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 // ListServices
@@ -24,7 +29,7 @@ func TestService_List(t *testing.T) {
 		APIListObject: listObj,
 		TeamIDs:       []string{},
 		TimeZone:      "foo",
-		SortBy:        "bar",
+		SortBy:        ServiceSortByNameDesc,
 		Query:         "baz",
 		Includes:      []string{},
 	}
@@ -47,6 +52,50 @@ func TestService_List(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+// ListServices with an unsupported include
+func TestService_ListInvalidInclude(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not have been sent")
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+	var opts = ListServiceOptions{
+		Includes: []string{ServiceIncludeTeams, "escalation_policy"},
+	}
+
+	_, err := client.ListServices(opts)
+	testErrCheck(t, "ListServices()", `unsupported service include "escalation_policy"`, err)
+
+	_, err = client.GetService("1", &GetServiceOptions{Includes: []string{"integration"}})
+	testErrCheck(t, "GetService()", `unsupported service include "integration"`, err)
+}
+
+// ListServices sorted by an unsupported field or direction
+func TestService_ListInvalidSortBy(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		testEqual(t, "name:desc", r.URL.Query().Get("sort_by"))
+		w.Write([]byte(`{"services": []}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	if _, err := client.ListServices(ListServiceOptions{SortBy: ServiceSortBy(ServiceSortByName, SortDirectionDesc)}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := client.ListServices(ListServiceOptions{SortBy: "created_at"})
+	testErrCheck(t, "ListServices()", `unsupported service sort field "created_at"`, err)
+
+	_, err = client.ListServicesPaginated(context.Background(), ListServiceOptions{SortBy: "name:up"})
+	testErrCheck(t, "ListServicesPaginated()", `unsupported sort direction "up"`, err)
+}
+
 // ListServices
 func TestService_ListPaginated(t *testing.T) {
 	setup()
@@ -70,53 +119,748 @@ func TestService_ListPaginated(t *testing.T) {
 		w.Write([]byte(resp))
 	})
 
-	var listObj = APIListObject{Limit: 1, Offset: 0, More: false, Total: 0}
+	var listObj = APIListObject{Limit: 1, Offset: 0, More: false, Total: 0}
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+	var opts = ListServiceOptions{
+		APIListObject: listObj,
+		TeamIDs:       []string{},
+		TimeZone:      "foo",
+		SortBy:        ServiceSortByNameDesc,
+		Query:         "baz",
+		Includes:      []string{},
+	}
+	res, err := client.ListServicesPaginated(context.Background(), opts)
+
+	want := []Service{
+		{
+			APIObject: APIObject{
+				ID: "0",
+			},
+		}, {
+			APIObject: APIObject{
+				ID: "1",
+			},
+		},
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, want, res)
+}
+
+// ListServicesPaginatedWithFunc
+// ListServices Paginated with a page size and prefetching
+func TestService_ListPaginatedPrefetch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	secondPage := make(chan struct{})
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "2", r.URL.Query().Get("limit"))
+		testEqual(t, "", r.URL.Query().Get("more"))
+
+		switch r.URL.Query().Get("offset") {
+		case "0":
+			w.Write([]byte(`{"services": [{"id": "1"}, {"id": "2"}], "offset": 0, "limit": 2, "more": true}`))
+		case "2":
+			close(secondPage)
+			w.Write([]byte(`{"services": [{"id": "3"}], "offset": 2, "limit": 2, "more": false}`))
+		default:
+			t.Errorf("unexpected offset %q", r.URL.Query().Get("offset"))
+		}
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	o := ListServiceOptions{
+		APIListObject: APIListObject{Limit: 2, More: true},
+		Prefetch:      true,
+	}
+
+	var ids []string
+	err := client.ListServicesPaginatedWithFunc(context.Background(), o, func(s Service) error {
+		if s.ID == "1" {
+			// the second page is requested while the first is processed
+			select {
+			case <-secondPage:
+			case <-time.After(5 * time.Second):
+				t.Error("the second page wasn't prefetched")
+			}
+		}
+		ids = append(ids, s.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, []string{"1", "2", "3"}, ids)
+}
+
+// ListServices Paginated with prefetching stopped by the callback
+func TestService_ListPaginatedPrefetchStop(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"services": [{"id": "1"}], "offset": 0, "limit": 1, "more": true}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	stop := errors.New("stop")
+	err := client.ListServicesPaginatedWithFunc(context.Background(), ListServiceOptions{Prefetch: true}, func(s Service) error {
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("err = %v, want %v", err, stop)
+	}
+}
+
+// List all Services
+func TestService_ListAll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "2", r.URL.Query().Get("limit"))
+
+		switch r.URL.Query().Get("offset") {
+		case "0":
+			w.Write([]byte(`{"services": [{"id": "1"}, {"id": "2"}], "offset": 0, "limit": 2, "more": true}`))
+		default:
+			w.Write([]byte(`{"services": [{"id": "3"}], "offset": 2, "limit": 2, "more": false}`))
+		}
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	o := ListServiceOptions{APIListObject: APIListObject{Limit: 2, Offset: 10}, ListAll: true}
+	res, err := client.ListServicesWithContext(context.Background(), o)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &ListServiceResponse{
+		Services: []Service{{APIObject: APIObject{ID: "1"}}, {APIObject: APIObject{ID: "2"}}, {APIObject: APIObject{ID: "3"}}},
+	}
+	testEqual(t, want, res)
+}
+
+// List Services by Escalation Policy
+func TestService_ListServicesByEscalationPolicy(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		switch r.URL.Query().Get("offset") {
+		case "0":
+			w.Write([]byte(`{"services": [{"id": "1", "name": "api", "escalation_policy": {"id": "PEP1"}}, {"id": "2", "name": "db", "escalation_policy": {"id": "PEP2"}}], "offset": 0, "limit": 2, "more": true}`))
+		default:
+			w.Write([]byte(`{"services": [{"id": "3", "name": "web", "escalation_policy": {"id": "PEP1"}}], "offset": 2, "limit": 2, "more": false}`))
+		}
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.ListServicesByEscalationPolicy(context.Background(), "PEP1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, s := range res {
+		names = append(names, s.ID+":"+s.Name)
+	}
+	testEqual(t, []string{"1:api", "3:web"}, names)
+}
+
+// Find Service by Integration Key
+func TestService_FindServiceByIntegrationKey(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var pages int
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "integrations", r.URL.Query().Get("include[]"))
+		pages++
+
+		switch r.URL.Query().Get("offset") {
+		case "0":
+			w.Write([]byte(`{"services": [{"id": "1", "integrations": [{"id": "PINT1", "integration_key": "abc"}]}], "offset": 0, "limit": 1, "more": true}`))
+		case "1":
+			w.Write([]byte(`{"services": [{"id": "2", "integrations": [{"id": "PINT2", "integration_key": "def"}]}], "offset": 1, "limit": 1, "more": true}`))
+		default:
+			w.Write([]byte(`{"services": [{"id": "3"}], "offset": 2, "limit": 1, "more": false}`))
+		}
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	s, in, err := client.FindServiceByIntegrationKey(context.Background(), "def")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, "2", s.ID)
+	testEqual(t, "PINT2", in.ID)
+	testEqual(t, 2, pages)
+
+	_, _, err = client.FindServiceByIntegrationKey(context.Background(), "xyz")
+	testErrCheck(t, "FindServiceByIntegrationKey()", "no service has an integration with key xyz", err)
+}
+
+// List Services Paginated, failing mid-way
+func TestService_ListPaginatedPartial(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		if offset >= 4 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintf(w, `{"services": [{"id": "%d"}, {"id": "%d"}], "more": true, "offset": %d, "limit": 2}`, offset, offset+1, offset)
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.ListServicesPaginated(context.Background(), ListServiceOptions{})
+
+	var perr PageError
+	if !errors.As(err, &perr) {
+		t.Fatalf("err = %v, want a PageError", err)
+	}
+	testEqual(t, uint(4), perr.Offset)
+	testEqual(t, uint(2), perr.Limit)
+
+	var aerr APIError
+	if !errors.As(err, &aerr) || aerr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("err = %v, want it to wrap the APIError", err)
+	}
+
+	testEqual(t, 4, len(res))
+}
+
+func TestService_ListPaginatedWithFunc(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests int
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		requests++
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		fmt.Fprintf(w, `{"services": [{"id": "%d"}, {"id": "%d"}], "more": true, "offset": %d, "limit": 2}`, offset, offset+1, offset)
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	errFound := errors.New("found")
+	var seen []string
+
+	err := client.ListServicesPaginatedWithFunc(context.Background(), ListServiceOptions{}, func(s Service) error {
+		seen = append(seen, s.ID)
+		if s.ID == "2" {
+			return errFound
+		}
+		return nil
+	})
+
+	if !errors.Is(err, errFound) {
+		t.Fatalf("err = %v, want %v", err, errFound)
+	}
+
+	testEqual(t, []string{"0", "1", "2"}, seen)
+
+	if requests != 2 {
+		t.Errorf("made %d requests, want 2", requests)
+	}
+}
+
+// ListServicesByLastIncident
+func TestService_ListByLastIncident(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if sortBy := r.URL.Query().Get("sort_by"); sortBy != "" {
+			t.Errorf("sort_by = %q, want it unset", sortBy)
+		}
+		w.Write([]byte(`{"services": [
+			{"id": "1", "last_incident_timestamp": "2020-10-01T10:00:00Z"},
+			{"id": "2"},
+			{"id": "3", "last_incident_timestamp": "2020-10-02T10:00:00Z"}
+		], "more": false}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+	res, err := client.ListServicesByLastIncident(context.Background(), ListServiceOptions{SortBy: ServiceSortByName})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, s := range res {
+		got = append(got, s.ID)
+	}
+	testEqual(t, []string{"3", "1", "2"}, got)
+
+	last, err := res[0].LastIncidentTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2020, 10, 2, 10, 0, 0, 0, time.UTC); !last.Equal(want) {
+		t.Errorf("LastIncidentTime() = %s, want %s", last, want)
+	}
+}
+
+// Get Service with its creation time
+func TestService_GetCreatedAt(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Write([]byte(`{"service": {"id": "1", "created_at": "2021-03-04T05:06:07Z", "integrations": [{"id": "PINT1", "created_at": "2021-03-05T00:00:00Z"}]}}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.GetServiceWithContext(context.Background(), "1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "2021-03-04T05:06:07Z", res.CreatedAt)
+	testEqual(t, res.CreatedAt, res.CreateAt)
+
+	created, err := res.CreatedAtTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC), created)
+
+	created, err = res.Integrations[0].CreatedAtTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, time.Date(2021, 3, 5, 0, 0, 0, 0, time.UTC), created)
+
+	if created, err := (Service{}).CreatedAtTime(); err != nil || !created.IsZero() {
+		t.Errorf("CreatedAtTime() = %s, %v, want the zero time", created, err)
+	}
+}
+
+// Get Service
+func TestService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Write([]byte(`{"service": {"id": "1","name":"foo"}}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	id := "1"
+	opts := &GetServiceOptions{
+		Includes: []string{},
+	}
+	res, err := client.GetService(id, opts)
+
+	want := &Service{
+		APIObject: APIObject{
+			ID: "1",
+		},
+		Name: "foo",
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, want, res)
+}
+
+// Get Service with a canceled context
+func TestService_GetWithContextCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/1", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not have been sent with a canceled context")
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.GetServiceWithContext(ctx, "1", &GetServiceOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+}
+
+// Get Service returning an API error
+func TestService_GetAPIError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": {"code": 2100, "message": "Not Found", "errors": ["Service not found"]}}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	_, err := client.GetService("1", &GetServiceOptions{})
+
+	var aerr APIError
+	if !errors.As(err, &aerr) {
+		t.Fatalf("err = %#v, want an APIError", err)
+	}
+
+	if aerr.StatusCode != http.StatusNotFound || !aerr.NotFound() {
+		t.Errorf("StatusCode = %d, NotFound() = %t, want 404 and true", aerr.StatusCode, aerr.NotFound())
+	}
+
+	want := APIErrorObject{Code: 2100, Message: "Not Found", Errors: []string{"Service not found"}}
+	testEqual(t, want, aerr.APIError.ErrorObject)
+}
+
+// Get Services by IDs
+func TestService_GetServicesByIDs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var (
+		mu       sync.Mutex
+		inFlight int
+		maxSeen  int
+	)
+
+	mux.HandleFunc("/services/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxSeen {
+			maxSeen = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		id := strings.TrimPrefix(r.URL.Path, "/services/")
+		if id == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"service": {"id": "%s"}}`, id)
+	})
+
+	client := NewClient("foo", WithAPIEndpoint(server.URL), WithBatchConcurrency(2))
+
+	ids := []string{"a", "b", "missing", "c", "d"}
+	res, err := client.GetServicesByIDs(context.Background(), ids, &GetServiceOptions{})
+
+	var berr *BatchError
+	if !errors.As(err, &berr) {
+		t.Fatalf("err = %v, want a *BatchError", err)
+	}
+	if len(berr.Errors) != 1 || berr.Errors[2] == nil {
+		t.Fatalf("BatchError.Errors = %v, want only index 2", berr.Errors)
+	}
+
+	var got []string
+	for _, s := range res {
+		got = append(got, s.ID)
+	}
+	testEqual(t, []string{"a", "b", "", "c", "d"}, got)
+
+	if maxSeen > 2 {
+		t.Errorf("saw %d concurrent requests, want at most 2", maxSeen)
+	}
+}
+
+// Create Service
+func TestService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		w.Write([]byte(`{"service": {"id": "1","name":"foo"}}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+	input := Service{
+		Name:             "foo",
+		EscalationPolicy: EscalationPolicy{APIObject: APIObject{ID: "PEP1"}},
+	}
+	res, err := client.CreateService(input)
+
+	want := &Service{
+		APIObject: APIObject{
+			ID: "1",
+		},
+		Name: "foo",
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, want, res)
+}
+
+// New Scheduled Action
+func TestNewScheduledAction(t *testing.T) {
+	a, err := NewScheduledAction(ScheduledActionSupportHoursStart, ScheduledActionUrgencyHigh)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := ScheduledAction{
+		Type: "urgency_change",
+		At: InlineModel{
+			Type: "named_time",
+			Name: "support_hours_start",
+		},
+		ToUrgency: "high",
+	}
+	testEqual(t, want, a)
+
+	_, err = NewScheduledAction(ScheduledActionSupportHoursEnd, "hihg")
+	testErrCheck(t, "NewScheduledAction()", `invalid scheduled action urgency "hihg"`, err)
+
+	_, err = NewScheduledAction("lunchtime", ScheduledActionUrgencyHigh)
+	testErrCheck(t, "NewScheduledAction()", `invalid scheduled action time "lunchtime"`, err)
+}
+
+// Create Service with an invalid ScheduledAction
+func TestService_CreateInvalidScheduledAction(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not have been sent")
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+	input := Service{
+		Name:             "foo",
+		EscalationPolicy: EscalationPolicy{APIObject: APIObject{ID: "PEP1"}},
+		ScheduledActions: []ScheduledAction{
+			{
+				Type:      "urgency_change",
+				At:        InlineModel{Type: "named_time", Name: "support_hours_start"},
+				ToUrgency: "High",
+			},
+		},
+	}
+
+	_, err := client.CreateService(input)
+	testErrCheck(t, "CreateService()", `scheduled action 0: invalid scheduled action urgency "High"`, err)
+}
+
+// Create Service with invalid SupportHours
+func TestService_CreateInvalidSupportHours(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		w.Write([]byte(`{"service": {"id": "1","name":"foo"}}`))
+	})
+
 	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
-	var opts = ListServiceOptions{
-		APIListObject: listObj,
-		TeamIDs:       []string{},
-		TimeZone:      "foo",
-		SortBy:        "bar",
-		Query:         "baz",
-		Includes:      []string{},
+
+	valid := SupportHours{
+		Type:       SupportHoursFixedTimePerDay,
+		Timezone:   "America/New_York",
+		StartTime:  "09:00:00",
+		EndTime:    "17:00:00",
+		DaysOfWeek: []uint{1, 2, 3, 4, 5},
 	}
-	res, err := client.ListServicesPaginated(context.Background(), opts)
 
-	want := []Service{
+	tests := []struct {
+		name      string
+		modify    func(h *SupportHours)
+		errString string
+	}{
 		{
-			APIObject: APIObject{
-				ID: "0",
+			name:   "valid",
+			modify: func(h *SupportHours) {},
+		},
+		{
+			name:      "no_timezone",
+			modify:    func(h *SupportHours) { h.Timezone = "" },
+			errString: "support hours must have a time zone",
+		},
+		{
+			name:      "bad_type",
+			modify:    func(h *SupportHours) { h.Type = "fixed_time" },
+			errString: `invalid support hours type "fixed_time"`,
+		},
+		{
+			name:      "bad_time",
+			modify:    func(h *SupportHours) { h.EndTime = "5pm" },
+			errString: `invalid support hours time "5pm"`,
+		},
+		{
+			name:      "sunday_zero",
+			modify:    func(h *SupportHours) { h.DaysOfWeek = []uint{0, 1} },
+			errString: "invalid support hours day of the week 0",
+		},
+		{
+			name:      "no_days",
+			modify:    func(h *SupportHours) { h.DaysOfWeek = nil },
+			errString: "support hours must have at least one day of the week",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			h := valid
+			tt.modify(&h)
+
+			input := Service{
+				Name:             "foo",
+				EscalationPolicy: EscalationPolicy{APIObject: APIObject{ID: "PEP1"}},
+				SupportHours:     &h,
+				ScheduledActions: []ScheduledAction{SupportHoursStartAction()},
+			}
+
+			_, err := client.CreateService(input)
+			testErrCheck(t, "CreateService()", tt.errString, err)
+		})
+	}
+}
+
+// Validate Service
+func TestValidateService(t *testing.T) {
+	valid := Service{
+		Name:             "foo",
+		EscalationPolicy: EscalationPolicy{APIObject: APIObject{ID: "PEP1"}},
+	}
+
+	tests := []struct {
+		name      string
+		modify    func(s *Service)
+		errString string
+	}{
+		{
+			name:   "valid",
+			modify: func(s *Service) {},
+		},
+		{
+			name:      "no_name",
+			modify:    func(s *Service) { s.Name = "" },
+			errString: "service must have a name",
+		},
+		{
+			name:      "no_escalation_policy",
+			modify:    func(s *Service) { s.EscalationPolicy = EscalationPolicy{} },
+			errString: "service must have an escalation policy",
+		},
+		{
+			name:      "invalid_status",
+			modify:    func(s *Service) { s.Status = "enabled" },
+			errString: `invalid service status "enabled"`,
+		},
+		{
+			name:      "invalid_alert_creation",
+			modify:    func(s *Service) { s.AlertCreation = "create_alerts" },
+			errString: `invalid alert creation "create_alerts"`,
+		},
+		{
+			name: "typed_values",
+			modify: func(s *Service) {
+				s.Status = ServiceStatusDisabled
+				s.AlertCreation = AlertCreationCreateAlertsAndIncidents
 			},
-		}, {
-			APIObject: APIObject{
-				ID: "1",
+		},
+		{
+			name:      "no_grouping_type",
+			modify:    func(s *Service) { s.AlertGroupingParameters = &AlertGroupingParameters{} },
+			errString: "alert grouping parameters must have a type",
+		},
+		{
+			name: "content_based_no_fields",
+			modify: func(s *Service) {
+				s.AlertGroupingParameters = &AlertGroupingParameters{
+					Type:   AlertGroupingTypeContentBased,
+					Config: AlertGroupParamsConfig{Aggregate: "all"},
+				}
+			},
+			errString: "content based alert grouping must have at least one field",
+		},
+		{
+			name: "content_based_no_aggregate",
+			modify: func(s *Service) {
+				s.AlertGroupingParameters = &AlertGroupingParameters{
+					Type:   AlertGroupingTypeContentBased,
+					Config: AlertGroupParamsConfig{Fields: []string{"source"}},
+				}
+			},
+			errString: `invalid content based alert grouping aggregate ""`,
+		},
+		{
+			name: "urgency_without_support_hours",
+			modify: func(s *Service) {
+				s.IncidentUrgencyRule = &IncidentUrgencyRule{Type: "use_support_hours"}
 			},
+			errString: "an incident urgency rule using support hours needs the service to have support hours",
+		},
+		{
+			name:      "scheduled_action_without_support_hours",
+			modify:    func(s *Service) { s.ScheduledActions = []ScheduledAction{SupportHoursStartAction()} },
+			errString: "scheduled actions need the service to have support hours",
 		},
 	}
 
-	if err != nil {
-		t.Fatal(err)
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			s := valid
+			tt.modify(&s)
+			testErrCheck(t, "ValidateService()", tt.errString, ValidateService(s))
+		})
 	}
-	testEqual(t, want, res)
 }
 
-// Get Service
-func TestService_Get(t *testing.T) {
+// Create Service With Response
+func TestService_CreateWithResponse(t *testing.T) {
 	setup()
 	defer teardown()
 
-	mux.HandleFunc("/services/1", func(w http.ResponseWriter, r *http.Request) {
-		testMethod(t, r, "GET")
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		w.Header().Set("X-Request-Id", "abc123")
 		w.Write([]byte(`{"service": {"id": "1","name":"foo"}}`))
 	})
 
 	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
-
-	id := "1"
-	opts := &GetServiceOptions{
-		Includes: []string{},
+	res, resp, err := client.CreateServiceWithResponse(context.Background(), Service{Name: "foo", EscalationPolicy: EscalationPolicy{APIObject: APIObject{ID: "PEP1"}}})
+	if err != nil {
+		t.Fatal(err)
 	}
-	res, err := client.GetService(id, opts)
 
 	want := &Service{
 		APIObject: APIObject{
@@ -124,40 +868,37 @@ func TestService_Get(t *testing.T) {
 		},
 		Name: "foo",
 	}
+	testEqual(t, want, res)
 
-	if err != nil {
-		t.Fatal(err)
+	if got := resp.Header.Get("X-Request-Id"); got != "abc123" {
+		t.Errorf("X-Request-Id = %q, want %q", got, "abc123")
 	}
-	testEqual(t, want, res)
 }
 
-// Create Service
-func TestService_Create(t *testing.T) {
+// Get Service With Response returning an API error
+func TestService_GetWithResponseAPIError(t *testing.T) {
 	setup()
 	defer teardown()
 
-	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
-		testMethod(t, r, "POST")
-		w.Write([]byte(`{"service": {"id": "1","name":"foo"}}`))
+	mux.HandleFunc("/services/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "def456")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": {"code": 2100, "message": "Not Found"}}`))
 	})
 
 	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
-	input := Service{
-		Name: "foo",
-	}
-	res, err := client.CreateService(input)
+	_, resp, err := client.GetServiceWithResponse(context.Background(), "1", nil)
+	testErrCheck(t, "GetServiceWithResponse()", "Not Found", err)
 
-	want := &Service{
-		APIObject: APIObject{
-			ID: "1",
-		},
-		Name: "foo",
+	if resp == nil {
+		t.Fatal("response is nil")
 	}
 
-	if err != nil {
-		t.Fatal(err)
+	if got := resp.Header.Get("X-Request-Id"); got != "def456" {
+		t.Errorf("X-Request-Id = %q, want %q", got, "def456")
 	}
-	testEqual(t, want, res)
 }
 
 // Create Service with AlertGroupingParameters of type time
@@ -172,7 +913,8 @@ func TestService_CreateWithAlertGroupParamsTime(t *testing.T) {
 
 	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
 	input := Service{
-		Name: "foo",
+		Name:             "foo",
+		EscalationPolicy: EscalationPolicy{APIObject: APIObject{ID: "PEP1"}},
 		AlertGroupingParameters: &AlertGroupingParameters{
 			Type: "time",
 			Config: AlertGroupParamsConfig{
@@ -207,7 +949,8 @@ func TestService_CreateWithAlertGroupParamsContentBased(t *testing.T) {
 
 	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
 	input := Service{
-		Name: "foo",
+		Name:             "foo",
+		EscalationPolicy: EscalationPolicy{APIObject: APIObject{ID: "PEP1"}},
 		AlertGroupingParameters: &AlertGroupingParameters{
 			Type: "content_based",
 			Config: AlertGroupParamsConfig{
@@ -243,7 +986,8 @@ func TestService_CreateWithAlertGroupParamsIntelligent(t *testing.T) {
 
 	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
 	input := Service{
-		Name: "foo",
+		Name:             "foo",
+		EscalationPolicy: EscalationPolicy{APIObject: APIObject{ID: "PEP1"}},
 		AlertGroupingParameters: &AlertGroupingParameters{
 			Type: "intelligent",
 		},
@@ -260,40 +1004,316 @@ func TestService_CreateWithAlertGroupParamsIntelligent(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	testEqual(t, want, res)
+	testEqual(t, want, res)
+}
+
+// Create and Get Service with AlertGroupingParameters round trip
+func TestService_AlertGroupParamsRoundTrip(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var stored []byte
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var body map[string]json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		stored = body["service"]
+		fmt.Fprintf(w, `{"service": %s}`, stored)
+	})
+	mux.HandleFunc("/services/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprintf(w, `{"service": %s}`, stored)
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	window := uint(0)
+	input := Service{
+		APIObject: APIObject{
+			ID: "1",
+		},
+		Name:             "foo",
+		EscalationPolicy: EscalationPolicy{APIObject: APIObject{ID: "PEP1"}},
+		AlertGroupingParameters: &AlertGroupingParameters{
+			Type: "content_based",
+			Config: AlertGroupParamsConfig{
+				Timeout:    300,
+				Aggregate:  "all",
+				Fields:     []string{"source", "summary"},
+				TimeWindow: &window,
+			},
+		},
+	}
+
+	if _, err := client.CreateService(input); err != nil {
+		t.Fatal(err)
+	}
+
+	var sent struct {
+		AlertGroupingParameters struct {
+			Config map[string]interface{} `json:"config"`
+		} `json:"alert_grouping_parameters"`
+	}
+	if err := json.Unmarshal(stored, &sent); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := sent.AlertGroupingParameters.Config["timeout"]; ok {
+		t.Error("timeout was sent for content_based grouping")
+	}
+
+	res, err := client.GetService("1", &GetServiceOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &AlertGroupingParameters{
+		Type: "content_based",
+		Config: AlertGroupParamsConfig{
+			Aggregate:  "all",
+			Fields:     []string{"source", "summary"},
+			TimeWindow: &window,
+		},
+	}
+	testEqual(t, want, res.AlertGroupingParameters)
+}
+
+// IsAlertGroupingActive
+func TestIsAlertGroupingActive(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want bool
+	}{
+		{
+			name: "reported_active",
+			json: `{"alert_grouping_active": true}`,
+			want: true,
+		},
+		{
+			name: "reported_learning",
+			json: `{"alert_creation": "create_alerts_and_incidents", "alert_grouping_parameters": {"type": "intelligent"}, "alert_grouping_active": false}`,
+			want: false,
+		},
+		{
+			name: "configured_parameters",
+			json: `{"alert_creation": "create_alerts_and_incidents", "alert_grouping_parameters": {"type": "time", "config": {"timeout": 2}}}`,
+			want: true,
+		},
+		{
+			name: "configured_legacy",
+			json: `{"alert_creation": "create_alerts_and_incidents", "alert_grouping": "intelligent"}`,
+			want: true,
+		},
+		{
+			name: "incidents_only",
+			json: `{"alert_creation": "create_incidents", "alert_grouping": "time"}`,
+			want: false,
+		},
+		{
+			name: "unconfigured",
+			json: `{"alert_creation": "create_alerts_and_incidents"}`,
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var s Service
+			if err := json.Unmarshal([]byte(tt.json), &s); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := IsAlertGroupingActive(s); got != tt.want {
+				t.Fatalf("IsAlertGroupingActive() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+// Update Service Fields
+func TestService_UpdateFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var body map[string]map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		testEqual(t, map[string]interface{}{"description": "bar", "auto_resolve_timeout": nil}, body["service"])
+
+		w.Write([]byte(`{"service": {"id": "1", "name": "foo", "description": "bar"}}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.UpdateServiceFields(context.Background(), "1", map[string]interface{}{
+		"description":          "bar",
+		"auto_resolve_timeout": nil,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, &Service{APIObject: APIObject{ID: "1"}, Name: "foo", Description: "bar"}, res)
+
+	_, err = client.UpdateServiceFields(context.Background(), "1", nil)
+	testErrCheck(t, "UpdateServiceFields()", "no fields to update on service 1", err)
+}
+
+// Update Service
+func TestService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		w.Write([]byte(`{"service": {"id": "1","name":"foo"}}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	input := Service{
+		APIObject: APIObject{
+			ID: "1",
+		},
+		Name: "foo",
+	}
+	res, err := client.UpdateService(input)
+
+	want := &Service{
+		APIObject: APIObject{
+			ID: "1",
+		},
+		Name: "foo",
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, want, res)
+}
+
+// Update Service settings that depend on the existing support hours
+func TestService_UpdatePartialSettings(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var puts int
+	mux.HandleFunc("/services/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		puts++
+		w.Write([]byte(`{"service": {"id": "1", "name": "foo"}}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	action, err := NewScheduledAction(ScheduledActionSupportHoursStart, ScheduledActionUrgencyHigh)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inputs := []Service{
+		{APIObject: APIObject{ID: "1"}, IncidentUrgencyRule: &IncidentUrgencyRule{Type: "use_support_hours"}},
+		{APIObject: APIObject{ID: "1"}, ScheduledActions: []ScheduledAction{action}},
+		{APIObject: APIObject{ID: "1"}, AlertGroupingParameters: &AlertGroupingParameters{}},
+	}
+	for _, input := range inputs {
+		if _, err := client.UpdateServiceWithContext(context.Background(), input); err != nil {
+			t.Fatal(err)
+		}
+	}
+	testEqual(t, len(inputs), puts)
+}
+
+// Create and Update Service with AutoPauseNotificationsParameters
+func TestService_AutoPauseNotificationsParameters(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var bodies []map[string]map[string]json.RawMessage
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]map[string]json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		bodies = append(bodies, body)
+		w.Write([]byte(`{"service": {"id": "1"}}`))
+	}
+	mux.HandleFunc("/services", handler)
+	mux.HandleFunc("/services/1", handler)
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	_, err := client.CreateService(Service{
+		Name:             "foo",
+		EscalationPolicy: EscalationPolicy{APIObject: APIObject{ID: "PEP1"}},
+		AutoPauseNotificationsParameters: &AutoPauseNotificationsParameters{
+			Enabled: true,
+			Timeout: AutoPauseTimeout10Minutes,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.UpdateService(Service{APIObject: APIObject{ID: "1"}, Name: "bar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := string(bodies[0]["service"]["auto_pause_notifications_parameters"]); got != `{"enabled":true,"timeout":600}` {
+		t.Errorf("create sent auto_pause_notifications_parameters = %s", got)
+	}
+
+	if got, ok := bodies[1]["service"]["auto_pause_notifications_parameters"]; ok {
+		t.Errorf("update without the field sent auto_pause_notifications_parameters = %s", got)
+	}
 }
 
-// Update Service
-func TestService_Update(t *testing.T) {
+// Set Auto Pause
+func TestService_SetAutoPause(t *testing.T) {
 	setup()
 	defer teardown()
 
 	mux.HandleFunc("/services/1", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "PUT")
-		w.Write([]byte(`{"service": {"id": "1","name":"foo"}}`))
+		var body map[string]map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if len(body["service"]) != 1 {
+			t.Errorf("request body = %v, want only auto_pause_notifications_parameters", body["service"])
+		}
+		w.Write([]byte(`{"service": {"id": "1", "auto_pause_notifications_parameters": {"enabled": true, "timeout": 300}}}`))
 	})
 
 	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
 
-	input := Service{
-		APIObject: APIObject{
-			ID: "1",
-		},
-		Name: "foo",
-	}
-	res, err := client.UpdateService(input)
+	res, err := client.SetAutoPause(context.Background(), "1", true, AutoPauseTimeout5Minutes)
 
 	want := &Service{
 		APIObject: APIObject{
 			ID: "1",
 		},
-		Name: "foo",
+		AutoPauseNotificationsParameters: &AutoPauseNotificationsParameters{
+			Enabled: true,
+			Timeout: 300,
+		},
 	}
 
 	if err != nil {
 		t.Fatal(err)
 	}
 	testEqual(t, want, res)
+
+	_, err = client.SetAutoPause(context.Background(), "1", true, 240)
+	testErrCheck(t, "SetAutoPause()", "not one of the allowed values", err)
 }
 
 // Delete Service
@@ -345,6 +1365,121 @@ func TestService_CreateIntegration(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+// New Events API v2 Integration
+func TestNewEventsV2Integration(t *testing.T) {
+	want := Integration{
+		Name:   "foo",
+		Type:   IntegrationTypeEventsAPIV2,
+		Vendor: &APIObject{ID: "V1", Type: "vendor_reference"},
+	}
+	testEqual(t, want, NewEventsV2Integration("foo", "V1"))
+	testEqual(t, Integration{Name: "foo", Type: IntegrationTypeEventsAPIV2}, NewEventsV2Integration("foo", ""))
+}
+
+// Create Integrations
+func TestService_CreateIntegrations(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/1/integrations", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var body map[string]Integration
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		in := body["integration"]
+		if in.Name == "bad" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": {"code": 2001, "message": "Invalid Input Provided"}}`))
+			return
+		}
+		fmt.Fprintf(w, `{"integration": {"id": "%s", "name": "%s"}}`, in.Name, in.Name)
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	input := []Integration{{Name: "a"}, {Name: "bad"}, {Name: "c"}}
+	res, err := client.CreateIntegrations(context.Background(), "1", input)
+
+	var berr *BatchError
+	if !errors.As(err, &berr) {
+		t.Fatalf("err = %v, want a *BatchError", err)
+	}
+	if len(berr.Errors) != 1 || berr.Errors[1] == nil {
+		t.Fatalf("BatchError.Errors = %v, want only index 1", berr.Errors)
+	}
+
+	want := []*Integration{
+		{APIObject: APIObject{ID: "a"}, Name: "a"},
+		nil,
+		{APIObject: APIObject{ID: "c"}, Name: "c"},
+	}
+	testEqual(t, want, res)
+}
+
+// Get Integration with an undecodable response
+func TestService_GetIntegrationDecodeError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/1/integrations/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"integration": `))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	_, err := client.GetIntegration("1", "1", GetIntegrationOptions{})
+	testErrCheck(t, "GetIntegration()", "Could not decode JSON response: failed to decode the response with status code 200: unexpected EOF", err)
+}
+
+// List Integrations
+func TestService_ListIntegrations(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got := r.URL.Query().Get("include[]"); got != "integrations" {
+			t.Errorf("include[] = %q, want %q", got, "integrations")
+		}
+		w.Write([]byte(`{"service": {"id": "1", "integrations": [{"id": "I1", "name": "foo", "vendor": {"id": "V1", "type": "vendor_reference"}}]}}`))
+	})
+	mux.HandleFunc("/services/2", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"service": {"id": "2"}}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.ListIntegrations(context.Background(), "1")
+
+	want := []Integration{
+		{
+			APIObject: APIObject{
+				ID: "I1",
+			},
+			Name: "foo",
+			Vendor: &APIObject{
+				ID:   "V1",
+				Type: "vendor_reference",
+			},
+		},
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, want, res)
+
+	res, err = client.ListIntegrations(context.Background(), "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res == nil || len(res) != 0 {
+		t.Fatalf("ListIntegrations() = %#v, want an empty, non-nil slice", res)
+	}
+}
+
 // Get Integration
 func TestService_GetIntegration(t *testing.T) {
 	setup()
@@ -384,6 +1519,13 @@ func TestService_UpdateIntegration(t *testing.T) {
 
 	mux.HandleFunc("/services/1/integrations/1", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "PUT")
+
+		var body map[string]map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		testEqual(t, "foo", body["integration"]["name"])
+
 		w.Write([]byte(`{"integration": {"id": "1","name":"foo"}}`))
 	})
 
@@ -411,6 +1553,167 @@ func TestService_UpdateIntegration(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+// Set Integration Enabled
+func TestService_SetIntegrationEnabled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/1/integrations/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		var body map[string]map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		testEqual(t, map[string]interface{}{"enabled": false}, body["integration"])
+		w.Write([]byte(`{"integration": {"id": "1", "name": "foo", "integration_key": "abc", "enabled": false}}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.SetIntegrationEnabled(context.Background(), "1", "1", false)
+
+	enabled := false
+	want := &Integration{
+		APIObject: APIObject{
+			ID: "1",
+		},
+		Name:           "foo",
+		IntegrationKey: "abc",
+		Enabled:        &enabled,
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, want, res)
+}
+
+// Regenerate Integration Key
+func TestService_RegenerateIntegrationKey(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var deleted bool
+	mux.HandleFunc("/services/1/integrations/1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"integration": {"id": "1", "name": "foo", "type": "events_api_v2_inbound_integration", "integration_key": "old", "vendor": {"id": "V1"}}}`))
+		case http.MethodDelete:
+			deleted = true
+		default:
+			t.Errorf("unexpected %s request", r.Method)
+		}
+	})
+	mux.HandleFunc("/services/1/integrations", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var body map[string]Integration
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		in := body["integration"]
+		if in.Name != "foo" || in.Type != "events_api_v2_inbound_integration" || in.IntegrationKey != "" {
+			t.Errorf("created integration = %#v, want a copy without the key", in)
+		}
+		w.Write([]byte(`{"integration": {"id": "2", "name": "foo", "type": "events_api_v2_inbound_integration", "integration_key": "new"}}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.RegenerateIntegrationKey(context.Background(), "1", "1")
+
+	want := &Integration{
+		APIObject: APIObject{
+			ID: "2",
+		},
+		Name:           "foo",
+		Type:           "events_api_v2_inbound_integration",
+		IntegrationKey: "new",
+		Vendor:         &APIObject{ID: "V1"},
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, want, res)
+
+	if !deleted {
+		t.Error("the original integration was not deleted")
+	}
+}
+
+// Regenerate the key of an email Integration
+func TestService_RegenerateIntegrationKeyEmail(t *testing.T) {
+	setup()
+	defer teardown()
+
+	const original = `{
+		"id": "1",
+		"type": "generic_email_inbound_integration",
+		"summary": "Email",
+		"self": "https://api.pagerduty.com/services/1/integrations/1",
+		"html_url": "https://subdomain.pagerduty.com/services/1/integrations/1",
+		"name": "Email",
+		"service": {"id": "1", "type": "service_reference"},
+		"created_at": "2020-10-19T03:06:16Z",
+		"integration_key": "old",
+		"integration_email": "alerts@subdomain.pagerduty.com",
+		"email_incident_creation": "use_rules",
+		"email_filter_mode": "and-rules-email",
+		"email_parsing_fallback": "discard",
+		"email_filters": [{"subject_mode": "match", "subject_regex": "^ALERT", "body_mode": "always", "from_email_mode": "always"}],
+		"email_parsers": [{"action": "trigger", "match_predicate": {"type": "all", "children": [{"type": "contains", "matcher": "down", "part": "subject"}]}, "value_extractors": [{"type": "regex", "part": "subject", "value_name": "incident_key", "regex": "host (\\S+)"}]}]
+	}`
+
+	var deleted bool
+	mux.HandleFunc("/services/1/integrations/1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"integration": ` + original + `}`))
+		case http.MethodDelete:
+			deleted = true
+		default:
+			t.Errorf("unexpected %s request", r.Method)
+		}
+	})
+	mux.HandleFunc("/services/1/integrations", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body map[string]map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		in := body["integration"]
+
+		for _, field := range []string{"id", "self", "html_url", "created_at", "integration_key"} {
+			if _, ok := in[field]; ok {
+				t.Errorf("created integration includes %q, which the API sets", field)
+			}
+		}
+
+		var want map[string]interface{}
+		if err := json.Unmarshal([]byte(original), &want); err != nil {
+			t.Fatal(err)
+		}
+		for _, field := range []string{"name", "type", "service", "integration_email", "email_incident_creation", "email_filter_mode", "email_parsing_fallback", "email_filters", "email_parsers"} {
+			testEqual(t, want[field], in[field])
+		}
+
+		w.Write([]byte(`{"integration": {"id": "2", "type": "generic_email_inbound_integration", "integration_email": "alerts@subdomain.pagerduty.com"}}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.RegenerateIntegrationKey(context.Background(), "1", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, "2", res.ID)
+
+	if !deleted {
+		t.Error("the original integration was not deleted")
+	}
+}
+
 // Delete Integration
 func TestService_DeleteIntegration(t *testing.T) {
 	setup()
@@ -458,6 +1761,41 @@ func TestService_ListRules(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+// List Service Rules one page at a time
+func TestService_ListRulesPaginated(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/1/rules", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		q := r.URL.Query()
+		if q.Get("total") != "true" || q.Get("limit") != "1" || q.Get("offset") != "1" {
+			t.Errorf("query = %q, want total=true, limit=1, and offset=1", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"rules": [{"id": "2"}], "offset": 1, "limit": 1, "more": true, "total": 3}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.ListServiceRulesPaginated(context.Background(), "1", APIListObject{Offset: 1, Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &ListServiceRulesResponse{
+		Offset: 1,
+		Limit:  1,
+		More:   true,
+		Total:  3,
+		Rules: []*ServiceRule{
+			{
+				ID: "2",
+			},
+		},
+	}
+	testEqual(t, want, res)
+}
+
 // Create Service Rule
 func TestService_CreateServiceRule(t *testing.T) {
 	setup()
@@ -484,6 +1822,206 @@ func TestService_CreateServiceRule(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+// Copy Service Rules
+func TestService_CopyServiceRules(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/1/rules", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Write([]byte(`{"rules": [
+			{"id": "R2", "self": "https://api.pagerduty.com/services/1/rules/R2", "position": 1, "disabled": true},
+			{"id": "R1", "self": "https://api.pagerduty.com/services/1/rules/R1", "position": 0}
+		], "more": false}`))
+	})
+
+	var posted []ServiceRule
+	mux.HandleFunc("/services/2/rules/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var body map[string]ServiceRule
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		posted = append(posted, body["rule"])
+		fmt.Fprintf(w, `{"rule": {"id": "N%d", "disabled": %t}}`, len(posted), body["rule"].Disabled)
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.CopyServiceRules(context.Background(), "1", "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, []ServiceRule{{}, {Disabled: true}}, posted)
+	testEqual(t, []*ServiceRule{{ID: "N1"}, {ID: "N2", Disabled: true}}, res)
+}
+
+// Reorder Service Rules
+func TestService_ReorderServiceRules(t *testing.T) {
+	tests := []struct {
+		name      string
+		failOn    int
+		want      []string
+		errString string
+	}{
+		{
+			name: "success",
+			want: []string{"R3", "R1", "R2"},
+		},
+		{
+			name:      "rollback",
+			failOn:    2,
+			want:      []string{"R1", "R2", "R3"},
+			errString: "failed to reorder service rules, the original order was restored",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			var mu sync.Mutex
+			order := []string{"R1", "R2", "R3"}
+			var puts int
+
+			mux.HandleFunc("/services/1/rules", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "GET")
+				mu.Lock()
+				defer mu.Unlock()
+
+				rules := make([]string, len(order))
+				for i, id := range order {
+					rules[i] = fmt.Sprintf(`{"id": %q, "position": %d}`, id, i)
+				}
+				fmt.Fprintf(w, `{"rules": [%s], "more": false}`, strings.Join(rules, ","))
+			})
+
+			mux.HandleFunc("/services/1/rules/", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "PUT")
+				mu.Lock()
+				defer mu.Unlock()
+
+				puts++
+				if puts == tt.failOn {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+
+				var body map[string]ServiceRule
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Fatal(err)
+				}
+				rule := body["rule"]
+
+				// move the rule to its new position, shifting the others
+				id := strings.TrimPrefix(r.URL.Path, "/services/1/rules/")
+				for i, o := range order {
+					if o == id {
+						order = append(order[:i], order[i+1:]...)
+						break
+					}
+				}
+				pos := *rule.Position
+				order = append(order[:pos], append([]string{id}, order[pos:]...)...)
+
+				fmt.Fprintf(w, `{"rule": {"id": %q, "position": %d}}`, id, pos)
+			})
+
+			var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+			err := client.ReorderServiceRules(context.Background(), "1", []string{"R3", "R1", "R2"})
+			testErrCheck(t, "ReorderServiceRules()", tt.errString, err)
+			testEqual(t, tt.want, order)
+		})
+	}
+}
+
+// Reorder Service Rules with IDs that don't match the service's rules
+func TestService_ReorderServiceRulesMismatch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/1/rules", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"rules": [{"id": "R1"}, {"id": "R2"}], "more": false}`))
+	})
+
+	mux.HandleFunc("/services/1/rules/", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no rule should have been updated")
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	err := client.ReorderServiceRules(context.Background(), "1", []string{"R2"})
+	testErrCheck(t, "ReorderServiceRules()", "service 1 has 2 rules, but 1 rule IDs were given", err)
+
+	err = client.ReorderServiceRules(context.Background(), "1", []string{"R2", "R3"})
+	testErrCheck(t, "ReorderServiceRules()", "rule R3 does not belong to service 1", err)
+
+	err = client.ReorderServiceRules(context.Background(), "1", []string{"R2", "R2"})
+	testErrCheck(t, "ReorderServiceRules()", "rule R2 is listed more than once", err)
+}
+
+// Create and Get Service Rule with nested conditions
+func TestService_ServiceRuleConditionsRoundTrip(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var stored json.RawMessage
+	mux.HandleFunc("/services/1/rules/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			var body map[string]json.RawMessage
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			stored = body["rule"]
+		case http.MethodGet:
+		default:
+			t.Errorf("unexpected %s request", r.Method)
+		}
+		fmt.Fprintf(w, `{"rule": %s}`, stored)
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	rule := &ServiceRule{
+		Conditions: &RuleConditions{
+			Operator: "or",
+			RuleSubconditions: []*RuleSubcondition{
+				{
+					Operator: "contains",
+					Parameters: &ConditionParameter{
+						Path:  "summary",
+						Value: "disk",
+					},
+				},
+				{
+					Operator: "matches",
+					Parameters: &ConditionParameter{
+						Path:  "source",
+						Value: "^db-.*",
+					},
+				},
+			},
+		},
+	}
+
+	if _, _, err := client.CreateServiceRule("1", rule); err != nil {
+		t.Fatal(err)
+	}
+
+	res, _, err := client.GetServiceRule("1", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, rule, res)
+}
+
 // Get Service Rule
 func TestService_GetServiceRule(t *testing.T) {
 	setup()
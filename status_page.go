@@ -0,0 +1,209 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-querystring/query"
+)
+
+// The values accepted by the StatusPageType field of ListStatusPagesOptions.
+const (
+	StatusPageTypePublic  = "public"
+	StatusPageTypePrivate = "private"
+)
+
+// The values accepted by the PostType field of StatusPagePost and
+// ListStatusPagePostsOptions.
+const (
+	StatusPagePostTypeIncident    = "incident"
+	StatusPagePostTypeMaintenance = "maintenance"
+)
+
+// StatusPage is a public or private status page of the account.
+type StatusPage struct {
+	ID             string `json:"id"`
+	Type           string `json:"type,omitempty"`
+	Name           string `json:"name,omitempty"`
+	StatusPageType string `json:"status_page_type,omitempty"`
+	URL            string `json:"url,omitempty"`
+	PublishedAt    string `json:"published_at,omitempty"`
+}
+
+// StatusPagePost is an incident or maintenance announced on a status page,
+// with the updates posted about it.
+type StatusPagePost struct {
+	ID         string     `json:"id,omitempty"`
+	Type       string     `json:"type"`
+	PostType   string     `json:"post_type"`
+	StatusPage *APIObject `json:"status_page,omitempty"`
+	Title      string     `json:"title"`
+
+	// StartsAt and EndsAt are the time range of a maintenance.
+	StartsAt string `json:"starts_at,omitempty"`
+	EndsAt   string `json:"ends_at,omitempty"`
+
+	// Updates are only sent when creating the post, as the status page post
+	// listing doesn't return them.
+	Updates []StatusPagePostUpdate `json:"updates,omitempty"`
+}
+
+// StatusPagePostUpdate is an update on a status page post. Status and
+// Severity refer to the statuses and severities configured for the status
+// page.
+type StatusPagePostUpdate struct {
+	ID                string                      `json:"id,omitempty"`
+	Type              string                      `json:"type"`
+	Message           string                      `json:"message"`
+	Status            APIObject                   `json:"status"`
+	Severity          APIObject                   `json:"severity"`
+	ImpactedServices  []StatusPageImpactedService `json:"impacted_services"`
+	NotifySubscribers bool                        `json:"notify_subscribers"`
+	ReportedAt        string                      `json:"reported_at,omitempty"`
+}
+
+// StatusPageImpactedService is a service of a status page affected by a post
+// update, and how severely.
+type StatusPageImpactedService struct {
+	Service  APIObject `json:"service"`
+	Severity APIObject `json:"severity"`
+}
+
+// ListStatusPagesOptions is the data structure used when calling the
+// ListStatusPages API endpoint.
+type ListStatusPagesOptions struct {
+	APIListObject
+	StatusPageType string `url:"status_page_type,omitempty"`
+
+	// ListAll makes ListStatusPages return every status page in a single response,
+	// with Limit as the page size. Offset is ignored, and ListAll isn't sent to
+	// the API.
+	ListAll bool `url:"-"`
+}
+
+// ListStatusPagesResponse is the data structure returned from calling the
+// ListStatusPages API endpoint.
+type ListStatusPagesResponse struct {
+	APIListObject
+	StatusPages []StatusPage `json:"status_pages"`
+}
+
+// ListStatusPagePostsOptions is the data structure used when calling the
+// ListStatusPagePosts API endpoint.
+type ListStatusPagePostsOptions struct {
+	APIListObject
+	PostType       string   `url:"post_type,omitempty"`
+	ReviewedStatus string   `url:"reviewed_status,omitempty"`
+	Statuses       []string `url:"status,omitempty,brackets"`
+
+	// ListAll makes ListStatusPagePosts return every post of the status page in a
+	// single response, with Limit as the page size. Offset is ignored, and ListAll
+	// isn't sent to the API.
+	ListAll bool `url:"-"`
+}
+
+// ListStatusPagePostsResponse is the data structure returned from calling the
+// ListStatusPagePosts API endpoint.
+type ListStatusPagePostsResponse struct {
+	APIListObject
+	Posts []StatusPagePost `json:"posts"`
+}
+
+// ListStatusPages lists the account's status pages.
+func (c *Client) ListStatusPages(ctx context.Context, o ListStatusPagesOptions) (*ListStatusPagesResponse, error) {
+	if o.ListAll {
+		var all ListStatusPagesResponse
+		responseHandler := func(response *http.Response) (APIListObject, error) {
+			var result ListStatusPagesResponse
+			if err := c.decodeJSON(response, &result); err != nil {
+				return APIListObject{}, err
+			}
+			all.StatusPages = append(all.StatusPages, result.StatusPages...)
+			return result.APIListObject, nil
+		}
+		if err := c.pagedGetAll(ctx, "/status_pages", o, responseHandler); err != nil {
+			return nil, err
+		}
+		return &all, nil
+	}
+
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.get(ctx, "/status_pages?"+v.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListStatusPagesResponse
+	return &result, c.decodeJSON(resp, &result)
+}
+
+// ListStatusPagePosts lists the posts of a status page, most recent first.
+func (c *Client) ListStatusPagePosts(ctx context.Context, statusPageID string, o ListStatusPagePostsOptions) (*ListStatusPagePostsResponse, error) {
+	if o.ListAll {
+		var all ListStatusPagePostsResponse
+		responseHandler := func(response *http.Response) (APIListObject, error) {
+			var result ListStatusPagePostsResponse
+			if err := c.decodeJSON(response, &result); err != nil {
+				return APIListObject{}, err
+			}
+			all.Posts = append(all.Posts, result.Posts...)
+			return result.APIListObject, nil
+		}
+		if err := c.pagedGetAll(ctx, "/status_pages/"+statusPageID+"/posts", o, responseHandler); err != nil {
+			return nil, err
+		}
+		return &all, nil
+	}
+
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.get(ctx, "/status_pages/"+statusPageID+"/posts?"+v.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListStatusPagePostsResponse
+	return &result, c.decodeJSON(resp, &result)
+}
+
+// CreateStatusPagePost publishes a new post on a status page, along with its
+// first update.
+func (c *Client) CreateStatusPagePost(ctx context.Context, statusPageID string, p StatusPagePost) (*StatusPagePost, error) {
+	p.Type = "status_page_post"
+	p.StatusPage = &APIObject{ID: statusPageID, Type: "status_page"}
+
+	// copy the updates so the caller's aren't modified
+	updates := make([]StatusPagePostUpdate, len(p.Updates))
+	for i, u := range p.Updates {
+		u.Type = "status_page_post_update"
+		updates[i] = u
+	}
+	p.Updates = updates
+
+	resp, err := c.post(ctx, "/status_pages/"+statusPageID+"/posts", wrapBody("post", p), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var target map[string]StatusPagePost
+	if dErr := c.decodeJSON(resp, &target); dErr != nil {
+		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
+	}
+
+	const rootNode = "post"
+
+	t, nodeOK := target[rootNode]
+	if !nodeOK {
+		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
+	}
+
+	return &t, nil
+}
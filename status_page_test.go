@@ -0,0 +1,109 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// List Status Pages
+func TestStatusPage_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/status_pages", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "public", r.URL.Query().Get("status_page_type"))
+		w.Write([]byte(`{"status_pages": [{"id": "PSP1", "name": "Acme", "status_page_type": "public", "url": "https://status.example.com"}], "more": false}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.ListStatusPages(context.Background(), ListStatusPagesOptions{StatusPageType: StatusPageTypePublic})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []StatusPage{{ID: "PSP1", Name: "Acme", StatusPageType: "public", URL: "https://status.example.com"}}
+	testEqual(t, want, res.StatusPages)
+}
+
+// List Status Page Posts
+func TestStatusPage_ListPosts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/status_pages/PSP1/posts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "incident", r.URL.Query().Get("post_type"))
+		w.Write([]byte(`{"posts": [{"id": "PPOST1", "type": "status_page_post", "post_type": "incident", "title": "Degraded API", "status_page": {"id": "PSP1", "type": "status_page"}}]}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.ListStatusPagePosts(context.Background(), "PSP1", ListStatusPagePostsOptions{PostType: StatusPagePostTypeIncident})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []StatusPagePost{
+		{
+			ID:         "PPOST1",
+			Type:       "status_page_post",
+			PostType:   "incident",
+			Title:      "Degraded API",
+			StatusPage: &APIObject{ID: "PSP1", Type: "status_page"},
+		},
+	}
+	testEqual(t, want, res.Posts)
+}
+
+// Create Status Page Post
+func TestStatusPage_CreatePost(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/status_pages/PSP1/posts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body map[string]StatusPagePost
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		p := body["post"]
+		testEqual(t, "status_page_post", p.Type)
+		testEqual(t, &APIObject{ID: "PSP1", Type: "status_page"}, p.StatusPage)
+		testEqual(t, "status_page_post_update", p.Updates[0].Type)
+		testEqual(t, []StatusPageImpactedService{{Service: APIObject{ID: "PSPSVC1", Type: "status_page_service"}, Severity: APIObject{ID: "PSEV1", Type: "status_page_severity"}}}, p.Updates[0].ImpactedServices)
+
+		w.Write([]byte(`{"post": {"id": "PPOST1", "type": "status_page_post", "post_type": "incident", "title": "Degraded API"}}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	severity := APIObject{ID: "PSEV1", Type: "status_page_severity"}
+	p := StatusPagePost{
+		PostType: StatusPagePostTypeIncident,
+		Title:    "Degraded API",
+		Updates: []StatusPagePostUpdate{
+			{
+				Message:  "We're looking into slow responses.",
+				Status:   APIObject{ID: "PSTAT1", Type: "status_page_status"},
+				Severity: severity,
+				ImpactedServices: []StatusPageImpactedService{
+					{Service: APIObject{ID: "PSPSVC1", Type: "status_page_service"}, Severity: severity},
+				},
+				NotifySubscribers: true,
+			},
+		},
+	}
+
+	res, err := client.CreateStatusPagePost(context.Background(), "PSP1", p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "PPOST1", res.ID)
+	testEqual(t, "", p.Updates[0].Type)
+}
@@ -59,32 +59,55 @@ type TagAssignment struct {
 
 // ListTags lists tags of your PagerDuty account, optionally filtered by a search query.
 func (c *Client) ListTags(o ListTagOptions) (*ListTagResponse, error) {
-	return getTagList(context.TODO(), c, "", "", o)
+	return c.ListTagsWithContext(context.TODO(), o)
+}
+
+// ListTagsWithContext lists tags of your PagerDuty account, optionally filtered by a search query.
+func (c *Client) ListTagsWithContext(ctx context.Context, o ListTagOptions) (*ListTagResponse, error) {
+	return getTagList(ctx, c, "", "", o)
 }
 
 // CreateTag creates a new tag.
 func (c *Client) CreateTag(t *Tag) (*Tag, *http.Response, error) {
-	data := make(map[string]*Tag)
-	data["tag"] = t
-	resp, err := c.post(context.TODO(), "/tags", data, nil)
+	return c.CreateTagWithContext(context.TODO(), t)
+}
+
+// CreateTagWithContext creates a new tag.
+func (c *Client) CreateTagWithContext(ctx context.Context, t *Tag) (*Tag, *http.Response, error) {
+	resp, err := c.post(ctx, "/tags", wrapBody("tag", t), nil)
 	return getTagFromResponse(c, resp, err)
 }
 
 // DeleteTag removes an existing tag.
 func (c *Client) DeleteTag(id string) error {
-	_, err := c.delete(context.TODO(), "/tags/"+id)
+	return c.DeleteTagWithContext(context.TODO(), id)
+}
+
+// DeleteTagWithContext removes an existing tag.
+func (c *Client) DeleteTagWithContext(ctx context.Context, id string) error {
+	_, err := c.delete(ctx, "/tags/"+id)
 	return err
 }
 
 // GetTag gets details about an existing tag.
 func (c *Client) GetTag(id string) (*Tag, *http.Response, error) {
-	resp, err := c.get(context.TODO(), "/tags/"+id)
+	return c.GetTagWithContext(context.TODO(), id)
+}
+
+// GetTagWithContext gets details about an existing tag.
+func (c *Client) GetTagWithContext(ctx context.Context, id string) (*Tag, *http.Response, error) {
+	resp, err := c.get(ctx, "/tags/"+id)
 	return getTagFromResponse(c, resp, err)
 }
 
 // AssignTags adds and removes tag assignments with entities
 func (c *Client) AssignTags(e, eid string, a *TagAssignments) (*http.Response, error) {
-	resp, err := c.post(context.TODO(), "/"+e+"/"+eid+"/change_tags", a, nil)
+	return c.AssignTagsWithContext(context.TODO(), e, eid, a)
+}
+
+// AssignTagsWithContext adds and removes tag assignments with entities
+func (c *Client) AssignTagsWithContext(ctx context.Context, e, eid string, a *TagAssignments) (*http.Response, error) {
+	resp, err := c.post(ctx, "/"+e+"/"+eid+"/change_tags", a, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -92,8 +115,50 @@ func (c *Client) AssignTags(e, eid string, a *TagAssignments) (*http.Response, e
 	return resp, nil
 }
 
+// AssignTagsByLabel adds and removes tags on an entity by their labels, such
+// as "users", "teams" or "escalation_policies" for e. Tags that are added and
+// don't exist yet are created. Removing a label the entity isn't tagged with
+// is a no-op. All of the changes are sent in a single request.
+func (c *Client) AssignTagsByLabel(ctx context.Context, e, eid string, add, remove []string) error {
+	a := &TagAssignments{}
+
+	for _, label := range add {
+		a.Add = append(a.Add, &TagAssignment{Type: "tag", Label: label})
+	}
+
+	if len(remove) > 0 {
+		current, err := c.GetTagsForEntityWithContext(ctx, e, eid, ListTagOptions{})
+		if err != nil {
+			return err
+		}
+
+		ids := make(map[string]string, len(current.Tags))
+		for _, t := range current.Tags {
+			ids[t.Label] = t.ID
+		}
+
+		for _, label := range remove {
+			if id, ok := ids[label]; ok {
+				a.Remove = append(a.Remove, &TagAssignment{Type: "tag_reference", TagID: id})
+			}
+		}
+	}
+
+	if len(a.Add) == 0 && len(a.Remove) == 0 {
+		return nil
+	}
+
+	_, err := c.AssignTagsWithContext(ctx, e, eid, a)
+	return err
+}
+
 // GetUsersByTag get related Users for the Tag.
 func (c *Client) GetUsersByTag(tid string) (*ListUserResponse, error) {
+	return c.GetUsersByTagWithContext(context.TODO(), tid)
+}
+
+// GetUsersByTagWithContext get related Users for the Tag.
+func (c *Client) GetUsersByTagWithContext(ctx context.Context, tid string) (*ListUserResponse, error) {
 	userResponse := new(ListUserResponse)
 	users := make([]*APIObject, 0)
 
@@ -117,7 +182,7 @@ func (c *Client) GetUsersByTag(tid string) (*ListUserResponse, error) {
 	}
 
 	// Make call to get all pages associated with the base endpoint.
-	if err := c.pagedGet(context.TODO(), "/tags/"+tid+"/users/", responseHandler); err != nil {
+	if err := c.pagedGet(ctx, "/tags/"+tid+"/users/", responseHandler); err != nil {
 		return nil, err
 	}
 	userResponse.Users = users
@@ -127,6 +192,11 @@ func (c *Client) GetUsersByTag(tid string) (*ListUserResponse, error) {
 
 // GetTeamsByTag get related Users for the Tag.
 func (c *Client) GetTeamsByTag(tid string) (*ListTeamsForTagResponse, error) {
+	return c.GetTeamsByTagWithContext(context.TODO(), tid)
+}
+
+// GetTeamsByTagWithContext get related Users for the Tag.
+func (c *Client) GetTeamsByTagWithContext(ctx context.Context, tid string) (*ListTeamsForTagResponse, error) {
 	teamsResponse := new(ListTeamsForTagResponse)
 	teams := make([]*APIObject, 0)
 
@@ -150,7 +220,7 @@ func (c *Client) GetTeamsByTag(tid string) (*ListTeamsForTagResponse, error) {
 	}
 
 	// Make call to get all pages associated with the base endpoint.
-	if err := c.pagedGet(context.TODO(), "/tags/"+tid+"/teams/", responseHandler); err != nil {
+	if err := c.pagedGet(ctx, "/tags/"+tid+"/teams/", responseHandler); err != nil {
 		return nil, err
 	}
 	teamsResponse.Teams = teams
@@ -160,6 +230,11 @@ func (c *Client) GetTeamsByTag(tid string) (*ListTeamsForTagResponse, error) {
 
 // GetEscalationPoliciesByTag get related Users for the Tag.
 func (c *Client) GetEscalationPoliciesByTag(tid string) (*ListEPResponse, error) {
+	return c.GetEscalationPoliciesByTagWithContext(context.TODO(), tid)
+}
+
+// GetEscalationPoliciesByTagWithContext get related Users for the Tag.
+func (c *Client) GetEscalationPoliciesByTagWithContext(ctx context.Context, tid string) (*ListEPResponse, error) {
 	epResponse := new(ListEPResponse)
 	eps := make([]*APIObject, 0)
 
@@ -183,7 +258,7 @@ func (c *Client) GetEscalationPoliciesByTag(tid string) (*ListEPResponse, error)
 	}
 
 	// Make call to get all pages associated with the base endpoint.
-	if err := c.pagedGet(context.TODO(), "/tags/"+tid+"/escalation_policies/", responseHandler); err != nil {
+	if err := c.pagedGet(ctx, "/tags/"+tid+"/escalation_policies/", responseHandler); err != nil {
 		return nil, err
 	}
 	epResponse.EscalationPolicies = eps
@@ -193,7 +268,12 @@ func (c *Client) GetEscalationPoliciesByTag(tid string) (*ListEPResponse, error)
 
 // GetTagsForEntity Get related tags for Users, Teams or Escalation Policies.
 func (c *Client) GetTagsForEntity(e, eid string, o ListTagOptions) (*ListTagResponse, error) {
-	return getTagList(context.TODO(), c, e, eid, o)
+	return c.GetTagsForEntityWithContext(context.TODO(), e, eid, o)
+}
+
+// GetTagsForEntityWithContext Get related tags for Users, Teams or Escalation Policies.
+func (c *Client) GetTagsForEntityWithContext(ctx context.Context, e, eid string, o ListTagOptions) (*ListTagResponse, error) {
+	return getTagList(ctx, c, e, eid, o)
 }
 
 func getTagFromResponse(c *Client, resp *http.Response, err error) (*Tag, *http.Response, error) {
@@ -244,7 +324,7 @@ func getTagList(ctx context.Context, c *Client, e, eid string, o ListTagOptions)
 		path = "/" + e + "/" + eid + "/tags"
 	}
 	// Make call to get all pages associated with the base endpoint.
-	if err := c.pagedGet(ctx, path+queryParms.Encode(), responseHandler); err != nil {
+	if err := c.pagedGet(ctx, path+"?"+queryParms.Encode(), responseHandler); err != nil {
 		return nil, err
 	}
 	tagResponse.Tags = tags
@@ -1,6 +1,8 @@
 package pagerduty
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"testing"
 )
@@ -168,6 +170,36 @@ func TestTag_AssignRemove(t *testing.T) {
 	}
 }
 
+// Assign Tags by label
+func TestTag_AssignTagsByLabel(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/PSVC1/tags", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Write([]byte(`{"tags": [{"id": "PTAG1", "label": "cost-center-a"}, {"id": "PTAG2", "label": "tier-1"}]}`))
+	})
+	mux.HandleFunc("/services/PSVC1/change_tags", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var got TagAssignments
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		want := TagAssignments{
+			Add:    []*TagAssignment{{Type: "tag", Label: "cost-center-b"}},
+			Remove: []*TagAssignment{{Type: "tag_reference", TagID: "PTAG1"}},
+		}
+		testEqual(t, want, got)
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	err := client.AssignTagsByLabel(context.Background(), "services", "PSVC1", []string{"cost-center-b"}, []string{"cost-center-a", "unknown"})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 // GetUsersByTag
 func TestTag_GetUsersByTag(t *testing.T) {
 	setup()
@@ -25,10 +25,30 @@ type ListTeamResponse struct {
 type ListTeamOptions struct {
 	APIListObject
 	Query string `url:"query,omitempty"`
+
+	// ListAll makes ListTeams return every team in a single response, with Limit
+	// as the page size. Offset is ignored, and ListAll isn't sent to the API.
+	ListAll bool `url:"-"`
 }
 
 // ListTeams lists teams of your PagerDuty account, optionally filtered by a search query.
 func (c *Client) ListTeams(o ListTeamOptions) (*ListTeamResponse, error) {
+	if o.ListAll {
+		var all ListTeamResponse
+		responseHandler := func(response *http.Response) (APIListObject, error) {
+			var result ListTeamResponse
+			if err := c.decodeJSON(response, &result); err != nil {
+				return APIListObject{}, err
+			}
+			all.Teams = append(all.Teams, result.Teams...)
+			return result.APIListObject, nil
+		}
+		if err := c.pagedGetAll(context.TODO(), "/teams", o, responseHandler); err != nil {
+			return nil, err
+		}
+		return &all, nil
+	}
+
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
@@ -44,7 +64,7 @@ func (c *Client) ListTeams(o ListTeamOptions) (*ListTeamResponse, error) {
 
 // CreateTeam creates a new team.
 func (c *Client) CreateTeam(t *Team) (*Team, error) {
-	resp, err := c.post(context.TODO(), "/teams", t, nil)
+	resp, err := c.post(context.TODO(), "/teams", wrapBody("team", t), nil)
 	return getTeamFromResponse(c, resp, err)
 }
 
@@ -62,7 +82,7 @@ func (c *Client) GetTeam(id string) (*Team, error) {
 
 // UpdateTeam updates an existing team.
 func (c *Client) UpdateTeam(id string, t *Team) (*Team, error) {
-	resp, err := c.put(context.TODO(), "/teams/"+id, t, nil)
+	resp, err := c.put(context.TODO(), "/teams/"+id, wrapBody("team", t), nil)
 	return getTeamFromResponse(c, resp, err)
 }
 
@@ -78,9 +98,21 @@ func (c *Client) AddEscalationPolicyToTeam(teamID, epID string) error {
 	return err
 }
 
+// Roles a user can hold as a member of a team.
+const (
+	TeamRoleObserver  = "observer"
+	TeamRoleResponder = "responder"
+	TeamRoleManager   = "manager"
+)
+
 // RemoveUserFromTeam removes a user from a team.
 func (c *Client) RemoveUserFromTeam(teamID, userID string) error {
-	_, err := c.delete(context.TODO(), "/teams/"+teamID+"/users/"+userID)
+	return c.RemoveUserFromTeamWithContext(context.TODO(), teamID, userID)
+}
+
+// RemoveUserFromTeamWithContext removes a user from a team.
+func (c *Client) RemoveUserFromTeamWithContext(ctx context.Context, teamID, userID string) error {
+	_, err := c.delete(ctx, "/teams/"+teamID+"/users/"+userID)
 	return err
 }
 
@@ -90,6 +122,18 @@ func (c *Client) AddUserToTeam(teamID, userID string) error {
 	return err
 }
 
+// AddUserToTeamWithRole adds a user to a team with the given role, or changes
+// the role of a user who is already a member. role should be one of the
+// TeamRole constants.
+func (c *Client) AddUserToTeamWithRole(ctx context.Context, teamID, userID, role string) error {
+	d := map[string]string{
+		"role": role,
+	}
+
+	_, err := c.put(ctx, "/teams/"+teamID+"/users/"+userID, d, nil)
+	return err
+}
+
 func getTeamFromResponse(c *Client, resp *http.Response, err error) (*Team, error) {
 	if err != nil {
 		return nil, err
@@ -117,6 +161,11 @@ type Member struct {
 // ListMembersOptions are the optional parameters for a members request.
 type ListMembersOptions struct {
 	APIListObject
+
+	// ListAll makes ListMembers return every member of the team in a single
+	// response, with Limit as the page size. Offset is ignored, and ListAll isn't
+	// sent to the API.
+	ListAll bool `url:"-"`
 }
 
 // ListMembersResponse is the response from the members endpoint.
@@ -127,6 +176,22 @@ type ListMembersResponse struct {
 
 // ListMembers gets the first page of users associated with the specified team.
 func (c *Client) ListMembers(teamID string, o ListMembersOptions) (*ListMembersResponse, error) {
+	if o.ListAll {
+		var all ListMembersResponse
+		responseHandler := func(response *http.Response) (APIListObject, error) {
+			var result ListMembersResponse
+			if err := c.decodeJSON(response, &result); err != nil {
+				return APIListObject{}, err
+			}
+			all.Members = append(all.Members, result.Members...)
+			return result.APIListObject, nil
+		}
+		if err := c.pagedGetAll(context.TODO(), "/teams/"+teamID+"/members", o, responseHandler); err != nil {
+			return nil, err
+		}
+		return &all, nil
+	}
+
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
@@ -142,6 +207,21 @@ func (c *Client) ListMembers(teamID string, o ListMembersOptions) (*ListMembersR
 
 // ListAllMembers gets all members associated with the specified team.
 func (c *Client) ListAllMembers(teamID string) ([]Member, error) {
+	return c.ListTeamMembers(context.TODO(), teamID, ListMembersOptions{})
+}
+
+// ListTeamMembers gets all members associated with the specified team,
+// following pagination until every page has been fetched. Any offset set in o
+// is ignored.
+func (c *Client) ListTeamMembers(ctx context.Context, teamID string, o ListMembersOptions) ([]Member, error) {
+	o.Offset = 0
+	o.More = false
+
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
 	members := make([]Member, 0)
 
 	// Create a handler closure capable of parsing data from the members endpoint
@@ -164,9 +244,135 @@ func (c *Client) ListAllMembers(teamID string) ([]Member, error) {
 	}
 
 	// Make call to get all pages associated with the base endpoint.
-	if err := c.pagedGet(context.TODO(), "/teams/"+teamID+"/members", responseHandler); err != nil {
+	if err := c.pagedGet(ctx, "/teams/"+teamID+"/members?"+v.Encode(), responseHandler); err != nil {
 		return nil, err
 	}
 
 	return members, nil
 }
+
+// NotificationSubscription is a subscription of a team or user to status
+// updates about a business service or other subscribable.
+type NotificationSubscription struct {
+	AccountID        string `json:"account_id,omitempty"`
+	SubscriberID     string `json:"subscriber_id,omitempty"`
+	SubscriberType   string `json:"subscriber_type,omitempty"`
+	SubscribableID   string `json:"subscribable_id,omitempty"`
+	SubscribableType string `json:"subscribable_type,omitempty"`
+
+	// Result is only set on subscriptions returned from a subscribe call,
+	// and reports whether that subscription was created.
+	Result string `json:"result,omitempty"`
+}
+
+// NotificationSubscribable is something that can be subscribed to for
+// notifications, like a business service.
+type NotificationSubscribable struct {
+	SubscribableID   string `json:"subscribable_id"`
+	SubscribableType string `json:"subscribable_type"`
+}
+
+// ListNotificationSubscriptionsResponse is the response from the team
+// notification subscriptions endpoint.
+type ListNotificationSubscriptionsResponse struct {
+	APIListObject
+	Subscriptions []NotificationSubscription `json:"subscriptions"`
+}
+
+// UnsubscribeNotificationsResult is the result of removing notification
+// subscriptions.
+type UnsubscribeNotificationsResult struct {
+	DeletedCount      uint `json:"deleted_count"`
+	UnauthorizedCount uint `json:"unauthorized_count"`
+	NonExistentCount  uint `json:"non_existent_count"`
+}
+
+func businessServiceSubscribables(businessServiceIDs []string) map[string][]NotificationSubscribable {
+	s := make([]NotificationSubscribable, 0, len(businessServiceIDs))
+	for _, id := range businessServiceIDs {
+		s = append(s, NotificationSubscribable{SubscribableID: id, SubscribableType: "business_service"})
+	}
+
+	return map[string][]NotificationSubscribable{"subscribables": s}
+}
+
+// ListTeamNotificationSubscriptions gets all of the notification
+// subscriptions held by a team.
+func (c *Client) ListTeamNotificationSubscriptions(ctx context.Context, teamID string) ([]NotificationSubscription, error) {
+	subscriptions := make([]NotificationSubscription, 0)
+
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		var result ListNotificationSubscriptionsResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return APIListObject{}, err
+		}
+
+		subscriptions = append(subscriptions, result.Subscriptions...)
+
+		return APIListObject{
+			More:   result.More,
+			Offset: result.Offset,
+			Limit:  result.Limit,
+		}, nil
+	}
+
+	if err := c.pagedGet(ctx, "/teams/"+teamID+"/notification_subscriptions", responseHandler); err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// SubscribeTeamToBusinessServices subscribes a team to notifications about
+// several business services with a single request. Check the Result field of
+// each returned subscription to see whether it was created.
+func (c *Client) SubscribeTeamToBusinessServices(ctx context.Context, teamID string, businessServiceIDs []string) ([]NotificationSubscription, error) {
+	resp, err := c.post(ctx, "/teams/"+teamID+"/notification_subscriptions", businessServiceSubscribables(businessServiceIDs), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListNotificationSubscriptionsResponse
+	if err := c.decodeJSON(resp, &result); err != nil {
+		return nil, fmt.Errorf("Could not decode JSON response: %v", err)
+	}
+
+	return result.Subscriptions, nil
+}
+
+// SubscribeTeamToBusinessService subscribes a team to notifications about a
+// business service.
+func (c *Client) SubscribeTeamToBusinessService(ctx context.Context, teamID, businessServiceID string) (*NotificationSubscription, error) {
+	subs, err := c.SubscribeTeamToBusinessServices(ctx, teamID, []string{businessServiceID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(subs) != 1 {
+		return nil, fmt.Errorf("expected 1 subscription in the response, got %d", len(subs))
+	}
+
+	return &subs[0], nil
+}
+
+// UnsubscribeTeamFromBusinessServices removes a team's notification
+// subscriptions to several business services with a single request.
+func (c *Client) UnsubscribeTeamFromBusinessServices(ctx context.Context, teamID string, businessServiceIDs []string) (*UnsubscribeNotificationsResult, error) {
+	resp, err := c.post(ctx, "/teams/"+teamID+"/notification_subscriptions/unsubscribe", businessServiceSubscribables(businessServiceIDs), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result UnsubscribeNotificationsResult
+	if err := c.decodeJSON(resp, &result); err != nil {
+		return nil, fmt.Errorf("Could not decode JSON response: %v", err)
+	}
+
+	return &result, nil
+}
+
+// UnsubscribeTeamFromBusinessService removes a team's notification
+// subscription to a business service.
+func (c *Client) UnsubscribeTeamFromBusinessService(ctx context.Context, teamID, businessServiceID string) (*UnsubscribeNotificationsResult, error) {
+	return c.UnsubscribeTeamFromBusinessServices(ctx, teamID, []string{businessServiceID})
+}
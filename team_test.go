@@ -2,6 +2,8 @@ package pagerduty
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"testing"
@@ -50,6 +52,13 @@ func TestTeam_Create(t *testing.T) {
 
 	mux.HandleFunc("/teams", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "POST")
+
+		var body map[string]map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		testEqual(t, "foo", body["team"]["name"])
+
 		w.Write([]byte(`{"team": {"id": "1","name":"foo"}}`))
 	})
 
@@ -124,6 +133,13 @@ func TestTeam_Update(t *testing.T) {
 
 	mux.HandleFunc("/teams/1", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "PUT")
+
+		var body map[string]map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		testEqual(t, "foo", body["team"]["name"])
+
 		w.Write([]byte(`{"team": {"id": "1","name":"foo"}}`))
 	})
 
@@ -231,6 +247,33 @@ func TestTeam_AddUserToTeam(t *testing.T) {
 	}
 }
 
+// Add User to Team with Role
+func TestTeam_AddUserToTeamWithRole(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/teams/1/users/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if body["role"] != TeamRoleManager {
+			t.Errorf("role = %q, want %q", body["role"], TeamRoleManager)
+		}
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+	teamID := "1"
+	userID := "1"
+
+	err := client.AddUserToTeamWithRole(context.Background(), teamID, userID, TeamRoleManager)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 func userID(offset, index int) int {
 	return offset + index
 }
@@ -422,3 +465,119 @@ func TestListAllMembersError(t *testing.T) {
 		t.Fatalf("Expected 0 members, got: %v", members)
 	}
 }
+
+func TestListTeamMembersMultiplePages(t *testing.T) {
+	setup()
+	defer teardown()
+
+	expectedNumResults := testMaxPageSize*2 + 1
+	currentPage := 0
+	pages := genRespPages(expectedNumResults, testMaxPageSize, genMembersRespPage, t)
+
+	mux.HandleFunc("/teams/"+testValidTeamID+"/members", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("limit"); got != "3" {
+			t.Errorf("limit = %q, want %q", got, "3")
+		}
+		fmt.Fprint(w, pages[currentPage])
+		currentPage++
+	})
+
+	api := &Client{apiEndpoint: server.URL, authToken: testAPIKey, HTTPClient: defaultHTTPClient}
+
+	opts := ListMembersOptions{APIListObject: APIListObject{Limit: testMaxPageSize}}
+	members, err := api.ListTeamMembers(context.Background(), testValidTeamID, opts)
+	if err != nil {
+		t.Fatalf("Failed to get members: %v", err)
+	}
+
+	if len(members) != expectedNumResults {
+		t.Fatalf("Expected %d team members, got: %d", expectedNumResults, len(members))
+	}
+
+	for _, m := range members {
+		if m.Role == "" {
+			t.Fatalf("Member %s has no role", m.APIObject.ID)
+		}
+	}
+}
+
+// List Team Notification Subscriptions
+func TestTeam_ListNotificationSubscriptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/teams/1/notification_subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Write([]byte(`{"subscriptions": [{"subscriber_id": "1", "subscriber_type": "team", "subscribable_id": "BS1", "subscribable_type": "business_service"}], "more": false}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.ListTeamNotificationSubscriptions(context.Background(), "1")
+
+	want := []NotificationSubscription{
+		{
+			SubscriberID:     "1",
+			SubscriberType:   "team",
+			SubscribableID:   "BS1",
+			SubscribableType: "business_service",
+		},
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, want, res)
+}
+
+// Subscribe Team to Business Services
+func TestTeam_SubscribeToBusinessServices(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/teams/1/notification_subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var body map[string][]NotificationSubscribable
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		testEqual(t, []NotificationSubscribable{{SubscribableID: "BS1", SubscribableType: "business_service"}, {SubscribableID: "BS2", SubscribableType: "business_service"}}, body["subscribables"])
+		w.Write([]byte(`{"subscriptions": [{"subscribable_id": "BS1", "result": "success"}, {"subscribable_id": "BS2", "result": "duplicate"}]}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.SubscribeTeamToBusinessServices(context.Background(), "1", []string{"BS1", "BS2"})
+
+	want := []NotificationSubscription{
+		{SubscribableID: "BS1", Result: "success"},
+		{SubscribableID: "BS2", Result: "duplicate"},
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, want, res)
+}
+
+// Unsubscribe Team from Business Service
+func TestTeam_UnsubscribeFromBusinessService(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/teams/1/notification_subscriptions/unsubscribe", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		w.Write([]byte(`{"deleted_count": 1, "unauthorized_count": 0, "non_existent_count": 0}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.UnsubscribeTeamFromBusinessService(context.Background(), "1", "BS1")
+
+	want := &UnsubscribeNotificationsResult{DeletedCount: 1}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, want, res)
+}
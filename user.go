@@ -59,12 +59,24 @@ type ListUsersResponse struct {
 	Users []User
 }
 
+// The values accepted by the Includes field of ListUsersOptions,
+// GetUserOptions and GetCurrentUserOptions.
+const (
+	UserIncludeContactMethods    = "contact_methods"
+	UserIncludeNotificationRules = "notification_rules"
+	UserIncludeTeams             = "teams"
+)
+
 // ListUsersOptions is the data structure used when calling the ListUsers API endpoint.
 type ListUsersOptions struct {
 	APIListObject
 	Query    string   `url:"query,omitempty"`
 	TeamIDs  []string `url:"team_ids,omitempty,brackets"`
 	Includes []string `url:"include,omitempty,brackets"`
+
+	// ListAll makes ListUsers return every user in a single response, with Limit
+	// as the page size. Offset is ignored, and ListAll isn't sent to the API.
+	ListAll bool `url:"-"`
 }
 
 // ListContactMethodsResponse is the data structure returned from calling the GetUserContactMethod API endpoint.
@@ -91,11 +103,32 @@ type GetCurrentUserOptions struct {
 
 // ListUsers lists users of your PagerDuty account, optionally filtered by a search query.
 func (c *Client) ListUsers(o ListUsersOptions) (*ListUsersResponse, error) {
+	return c.ListUsersWithContext(context.TODO(), o)
+}
+
+// ListUsersWithContext lists users of your PagerDuty account, optionally filtered by a search query.
+func (c *Client) ListUsersWithContext(ctx context.Context, o ListUsersOptions) (*ListUsersResponse, error) {
+	if o.ListAll {
+		var all ListUsersResponse
+		responseHandler := func(response *http.Response) (APIListObject, error) {
+			var result ListUsersResponse
+			if err := c.decodeJSON(response, &result); err != nil {
+				return APIListObject{}, err
+			}
+			all.Users = append(all.Users, result.Users...)
+			return result.APIListObject, nil
+		}
+		if err := c.pagedGetAll(ctx, "/users", o, responseHandler); err != nil {
+			return nil, err
+		}
+		return &all, nil
+	}
+
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.get(context.TODO(), "/users?"+v.Encode())
+	resp, err := c.get(ctx, "/users?"+v.Encode())
 	if err != nil {
 		return nil, err
 	}
@@ -105,46 +138,114 @@ func (c *Client) ListUsers(o ListUsersOptions) (*ListUsersResponse, error) {
 
 // CreateUser creates a new user.
 func (c *Client) CreateUser(u User) (*User, error) {
-	data := make(map[string]User)
-	data["user"] = u
-	resp, err := c.post(context.TODO(), "/users", data, nil)
+	return c.CreateUserWithContext(context.TODO(), u)
+}
+
+// CreateUserWithContext creates a new user.
+func (c *Client) CreateUserWithContext(ctx context.Context, u User) (*User, error) {
+	resp, err := c.post(ctx, "/users", wrapBody("user", u), nil)
 	return getUserFromResponse(c, resp, err)
 }
 
 // DeleteUser deletes a user.
 func (c *Client) DeleteUser(id string) error {
-	_, err := c.delete(context.TODO(), "/users/"+id)
+	return c.DeleteUserWithContext(context.TODO(), id)
+}
+
+// DeleteUserWithContext deletes a user.
+func (c *Client) DeleteUserWithContext(ctx context.Context, id string) error {
+	_, err := c.delete(ctx, "/users/"+id)
 	return err
 }
 
 // GetUser gets details about an existing user.
 func (c *Client) GetUser(id string, o GetUserOptions) (*User, error) {
+	return c.GetUserWithContext(context.TODO(), id, o)
+}
+
+// GetUserWithContext gets details about an existing user.
+func (c *Client) GetUserWithContext(ctx context.Context, id string, o GetUserOptions) (*User, error) {
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.get(context.TODO(), "/users/"+id+"?"+v.Encode())
+	resp, err := c.get(ctx, "/users/"+id+"?"+v.Encode())
 	return getUserFromResponse(c, resp, err)
 }
 
 // UpdateUser updates an existing user.
 func (c *Client) UpdateUser(u User) (*User, error) {
-	v := make(map[string]User)
-	v["user"] = u
-	resp, err := c.put(context.TODO(), "/users/"+u.ID, v, nil)
+	return c.UpdateUserWithContext(context.TODO(), u)
+}
+
+// UpdateUserWithContext updates an existing user.
+func (c *Client) UpdateUserWithContext(ctx context.Context, u User) (*User, error) {
+	resp, err := c.put(ctx, "/users/"+u.ID, wrapBody("user", u), nil)
 	return getUserFromResponse(c, resp, err)
 }
 
 // GetCurrentUser gets details about the authenticated user when using a user-level API key or OAuth token
 func (c *Client) GetCurrentUser(o GetCurrentUserOptions) (*User, error) {
+	return c.GetCurrentUserWithContext(context.TODO(), o)
+}
+
+// GetCurrentUserWithContext gets details about the authenticated user when using a user-level API key or OAuth token
+func (c *Client) GetCurrentUserWithContext(ctx context.Context, o GetCurrentUserOptions) (*User, error) {
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.get(context.TODO(), "/users/me?"+v.Encode())
+	resp, err := c.get(ctx, "/users/me?"+v.Encode())
 	return getUserFromResponse(c, resp, err)
 }
 
+// CurrentUserEmail returns the email address of the user behind the client's
+// token, as needed for the From header of incident calls. It's fetched once
+// with GetCurrentUserWithContext and cached on the client; use
+// ClearCurrentUserCache to force it to be refetched.
+func (c *Client) CurrentUserEmail(ctx context.Context) (string, error) {
+	c.currentUserMu.Lock()
+	defer c.currentUserMu.Unlock()
+
+	if c.currentUserEmail == "" {
+		u, err := c.GetCurrentUserWithContext(ctx, GetCurrentUserOptions{})
+		if err != nil {
+			return "", err
+		}
+
+		if u.Email == "" {
+			return "", fmt.Errorf("current user %s has no email address", u.ID)
+		}
+
+		c.currentUserEmail = u.Email
+	}
+
+	return c.currentUserEmail, nil
+}
+
+// ClearCurrentUserCache discards the email address cached by
+// CurrentUserEmail.
+func (c *Client) ClearCurrentUserCache() {
+	c.currentUserMu.Lock()
+	c.currentUserEmail = ""
+	c.currentUserMu.Unlock()
+}
+
+// resolveFrom returns the From header to send for a call made with from. An
+// empty from is replaced by the WithDefaultFrom address, or by the current
+// user's email address when the client was created WithCurrentUserFrom.
+func (c *Client) resolveFrom(ctx context.Context, from string) (string, error) {
+	if from != "" {
+		return from, nil
+	}
+
+	if c.defaultFrom != "" || !c.fromCurrentUser {
+		return c.defaultFrom, nil
+	}
+
+	return c.CurrentUserEmail(ctx)
+}
+
 func getUserFromResponse(c *Client, resp *http.Response, err error) (*User, error) {
 	if err != nil {
 		return nil, err
@@ -163,7 +264,12 @@ func getUserFromResponse(c *Client, resp *http.Response, err error) (*User, erro
 
 // ListUserContactMethods fetches contact methods of the existing user.
 func (c *Client) ListUserContactMethods(userID string) (*ListContactMethodsResponse, error) {
-	resp, err := c.get(context.TODO(), "/users/"+userID+"/contact_methods")
+	return c.ListUserContactMethodsWithContext(context.TODO(), userID)
+}
+
+// ListUserContactMethodsWithContext fetches contact methods of the existing user.
+func (c *Client) ListUserContactMethodsWithContext(ctx context.Context, userID string) (*ListContactMethodsResponse, error) {
+	resp, err := c.get(ctx, "/users/"+userID+"/contact_methods")
 	if err != nil {
 		return nil, err
 	}
@@ -173,29 +279,45 @@ func (c *Client) ListUserContactMethods(userID string) (*ListContactMethodsRespo
 
 // GetUserContactMethod gets details about a contact method.
 func (c *Client) GetUserContactMethod(userID, contactMethodID string) (*ContactMethod, error) {
-	resp, err := c.get(context.TODO(), "/users/"+userID+"/contact_methods/"+contactMethodID)
+	return c.GetUserContactMethodWithContext(context.TODO(), userID, contactMethodID)
+}
+
+// GetUserContactMethodWithContext gets details about a contact method.
+func (c *Client) GetUserContactMethodWithContext(ctx context.Context, userID, contactMethodID string) (*ContactMethod, error) {
+	resp, err := c.get(ctx, "/users/"+userID+"/contact_methods/"+contactMethodID)
 	return getContactMethodFromResponse(c, resp, err)
 }
 
 // DeleteUserContactMethod deletes a user.
 func (c *Client) DeleteUserContactMethod(userID, contactMethodID string) error {
-	_, err := c.delete(context.TODO(), "/users/"+userID+"/contact_methods/"+contactMethodID)
+	return c.DeleteUserContactMethodWithContext(context.TODO(), userID, contactMethodID)
+}
+
+// DeleteUserContactMethodWithContext deletes a user.
+func (c *Client) DeleteUserContactMethodWithContext(ctx context.Context, userID, contactMethodID string) error {
+	_, err := c.delete(ctx, "/users/"+userID+"/contact_methods/"+contactMethodID)
 	return err
 }
 
 // CreateUserContactMethod creates a new contact method for user.
 func (c *Client) CreateUserContactMethod(userID string, cm ContactMethod) (*ContactMethod, error) {
-	data := make(map[string]ContactMethod)
-	data["contact_method"] = cm
-	resp, err := c.post(context.TODO(), "/users/"+userID+"/contact_methods", data, nil)
+	return c.CreateUserContactMethodWithContext(context.TODO(), userID, cm)
+}
+
+// CreateUserContactMethodWithContext creates a new contact method for user.
+func (c *Client) CreateUserContactMethodWithContext(ctx context.Context, userID string, cm ContactMethod) (*ContactMethod, error) {
+	resp, err := c.post(ctx, "/users/"+userID+"/contact_methods", wrapBody("contact_method", cm), nil)
 	return getContactMethodFromResponse(c, resp, err)
 }
 
 // UpdateUserContactMethod updates an existing user.
 func (c *Client) UpdateUserContactMethod(userID string, cm ContactMethod) (*ContactMethod, error) {
-	v := make(map[string]ContactMethod)
-	v["contact_method"] = cm
-	resp, err := c.put(context.TODO(), "/users/"+userID+"/contact_methods/"+cm.ID, v, nil)
+	return c.UpdateUserContactMethodWithContext(context.TODO(), userID, cm)
+}
+
+// UpdateUserContactMethodWithContext updates an existing user.
+func (c *Client) UpdateUserContactMethodWithContext(ctx context.Context, userID string, cm ContactMethod) (*ContactMethod, error) {
+	resp, err := c.put(ctx, "/users/"+userID+"/contact_methods/"+cm.ID, wrapBody("contact_method", cm), nil)
 	return getContactMethodFromResponse(c, resp, err)
 }
 
@@ -217,35 +339,56 @@ func getContactMethodFromResponse(c *Client, resp *http.Response, err error) (*C
 
 // GetUserNotificationRule gets details about a notification rule.
 func (c *Client) GetUserNotificationRule(userID, ruleID string) (*NotificationRule, error) {
-	resp, err := c.get(context.TODO(), "/users/"+userID+"/notification_rules/"+ruleID)
+	return c.GetUserNotificationRuleWithContext(context.TODO(), userID, ruleID)
+}
+
+// GetUserNotificationRuleWithContext gets details about a notification rule.
+func (c *Client) GetUserNotificationRuleWithContext(ctx context.Context, userID, ruleID string) (*NotificationRule, error) {
+	resp, err := c.get(ctx, "/users/"+userID+"/notification_rules/"+ruleID)
 	return getUserNotificationRuleFromResponse(c, resp, err)
 }
 
 // CreateUserNotificationRule creates a new notification rule for a user.
 func (c *Client) CreateUserNotificationRule(userID string, rule NotificationRule) (*NotificationRule, error) {
-	data := make(map[string]NotificationRule)
-	data["notification_rule"] = rule
-	resp, err := c.post(context.TODO(), "/users/"+userID+"/notification_rules", data, nil)
+	return c.CreateUserNotificationRuleWithContext(context.TODO(), userID, rule)
+}
+
+// CreateUserNotificationRuleWithContext creates a new notification rule for a user.
+func (c *Client) CreateUserNotificationRuleWithContext(ctx context.Context, userID string, rule NotificationRule) (*NotificationRule, error) {
+	resp, err := c.post(ctx, "/users/"+userID+"/notification_rules", wrapBody("notification_rule", rule), nil)
 	return getUserNotificationRuleFromResponse(c, resp, err)
 }
 
 // UpdateUserNotificationRule updates a notification rule for a user.
 func (c *Client) UpdateUserNotificationRule(userID string, rule NotificationRule) (*NotificationRule, error) {
-	data := make(map[string]NotificationRule)
-	data["notification_rule"] = rule
-	resp, err := c.put(context.TODO(), "/users/"+userID+"/notification_rules/"+rule.ID, data, nil)
+	return c.UpdateUserNotificationRuleWithContext(context.TODO(), userID, rule)
+}
+
+// UpdateUserNotificationRuleWithContext updates a notification rule for a user.
+func (c *Client) UpdateUserNotificationRuleWithContext(ctx context.Context, userID string, rule NotificationRule) (*NotificationRule, error) {
+	resp, err := c.put(ctx, "/users/"+userID+"/notification_rules/"+rule.ID, wrapBody("notification_rule", rule), nil)
 	return getUserNotificationRuleFromResponse(c, resp, err)
 }
 
 // DeleteUserNotificationRule deletes a notification rule for a user.
 func (c *Client) DeleteUserNotificationRule(userID, ruleID string) error {
-	_, err := c.delete(context.TODO(), "/users/"+userID+"/notification_rules/"+ruleID)
+	return c.DeleteUserNotificationRuleWithContext(context.TODO(), userID, ruleID)
+}
+
+// DeleteUserNotificationRuleWithContext deletes a notification rule for a user.
+func (c *Client) DeleteUserNotificationRuleWithContext(ctx context.Context, userID, ruleID string) error {
+	_, err := c.delete(ctx, "/users/"+userID+"/notification_rules/"+ruleID)
 	return err
 }
 
 // ListUserNotificationRules fetches notification rules of the existing user.
 func (c *Client) ListUserNotificationRules(userID string) (*ListUserNotificationRulesResponse, error) {
-	resp, err := c.get(context.TODO(), "/users/"+userID+"/notification_rules")
+	return c.ListUserNotificationRulesWithContext(context.TODO(), userID)
+}
+
+// ListUserNotificationRulesWithContext fetches notification rules of the existing user.
+func (c *Client) ListUserNotificationRulesWithContext(ctx context.Context, userID string) (*ListUserNotificationRulesResponse, error) {
+	resp, err := c.get(ctx, "/users/"+userID+"/notification_rules")
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,8 @@
 package pagerduty
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"testing"
 )
@@ -119,6 +121,35 @@ func TestUser_Get(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+// Get user with includes
+func TestUser_GetWithContextIncludes(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, []string{UserIncludeContactMethods, UserIncludeNotificationRules}, r.URL.Query()["include[]"])
+		w.Write([]byte(`{"user": {"id": "1", "contact_methods": [{"id": "PCM1", "type": "email_contact_method"}]}}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+	opts := GetUserOptions{
+		Includes: []string{UserIncludeContactMethods, UserIncludeNotificationRules},
+	}
+	res, err := client.GetUserWithContext(context.Background(), "1", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &User{
+		APIObject: APIObject{
+			ID: "1",
+		},
+		ContactMethods: []ContactMethod{{ID: "PCM1", Type: "email_contact_method"}},
+	}
+	testEqual(t, want, res)
+}
+
 // Update
 func TestUser_Update(t *testing.T) {
 	setup()
@@ -377,6 +408,44 @@ func TestUser_CreateUserNotificationRule(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+// Create user NotificationRule with context
+func TestUser_CreateUserNotificationRuleWithContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/1/notification_rules", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var body struct {
+			NotificationRule NotificationRule `json:"notification_rule"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		testEqual(t, "high", body.NotificationRule.Urgency)
+		testEqual(t, uint(0), body.NotificationRule.StartDelayInMinutes)
+		testEqual(t, "PCM1", body.NotificationRule.ContactMethod.ID)
+		w.Write([]byte(`{"notification_rule": {"id": "1", "start_delay_in_minutes": 0, "urgency": "high", "contact_method": {"id": "PCM1"}}}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+	rule := NotificationRule{
+		Type:          "assignment_notification_rule",
+		Urgency:       "high",
+		ContactMethod: ContactMethod{ID: "PCM1", Type: "email_contact_method_reference"},
+	}
+	res, err := client.CreateUserNotificationRuleWithContext(context.Background(), "1", rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &NotificationRule{
+		ID:            "1",
+		Urgency:       "high",
+		ContactMethod: ContactMethod{ID: "PCM1"},
+	}
+	testEqual(t, want, res)
+}
+
 // List User NotificationRules
 func TestUser_ListUserNotificationRules(t *testing.T) {
 	setup()
@@ -462,3 +531,40 @@ func TestUser_DeleteUserNotificationRule(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// Create Incident with the From of the current user
+func TestUser_CurrentUserFrom(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var lookups int
+	mux.HandleFunc("/users/me", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		lookups++
+		w.Write([]byte(`{"user": {"id": "PUSER1", "email": "me@example.com"}}`))
+	})
+
+	var from string
+	mux.HandleFunc("/incidents", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		from = r.Header.Get("From")
+		w.Write([]byte(`{"incident": {"id": "PINC1"}}`))
+	})
+
+	client := NewClient("foo", WithAPIEndpoint(server.URL), WithCurrentUserFrom())
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.CreateIncidentWithContext(context.Background(), "", &CreateIncidentOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		testEqual(t, "me@example.com", from)
+	}
+	testEqual(t, 1, lookups)
+
+	// an explicit from is sent as is
+	if _, err := client.CreateIncidentWithContext(context.Background(), "other@example.com", &CreateIncidentOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, "other@example.com", from)
+	testEqual(t, 1, lookups)
+}
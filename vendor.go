@@ -35,16 +35,34 @@ type ListVendorResponse struct {
 type ListVendorOptions struct {
 	APIListObject
 	Query string `url:"query,omitempty"`
+
+	// ListAll makes ListVendors return the whole vendor catalog in a single
+	// response, like ListVendorsPaginated. Limit is then the page size and
+	// Offset is ignored. It isn't sent to the API.
+	ListAll bool `url:"-"`
 }
 
 // ListVendors lists existing vendors.
 func (c *Client) ListVendors(o ListVendorOptions) (*ListVendorResponse, error) {
+	return c.ListVendorsWithContext(context.TODO(), o)
+}
+
+// ListVendorsWithContext lists existing vendors.
+func (c *Client) ListVendorsWithContext(ctx context.Context, o ListVendorOptions) (*ListVendorResponse, error) {
+	if o.ListAll {
+		vendors, err := c.ListVendorsPaginated(ctx, o)
+		if err != nil {
+			return nil, err
+		}
+		return &ListVendorResponse{Vendors: vendors}, nil
+	}
+
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.get(context.TODO(), "/vendors?"+v.Encode())
+	resp, err := c.get(ctx, "/vendors?"+v.Encode())
 	if err != nil {
 		return nil, err
 	}
@@ -53,9 +71,44 @@ func (c *Client) ListVendors(o ListVendorOptions) (*ListVendorResponse, error) {
 	return &result, c.decodeJSON(resp, &result)
 }
 
+// ListVendorsPaginated lists existing vendors, processing paginated responses
+// so the full vendor catalog is returned. o.Offset is ignored.
+func (c *Client) ListVendorsPaginated(ctx context.Context, o ListVendorOptions) ([]Vendor, error) {
+	o.APIListObject = APIListObject{Limit: o.Limit}
+
+	var vendors []Vendor
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		var result ListVendorResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return APIListObject{}, err
+		}
+
+		vendors = append(vendors, result.Vendors...)
+
+		return APIListObject{
+			More:   result.More,
+			Offset: result.Offset,
+			Limit:  result.Limit,
+		}, nil
+	}
+	if err := c.pagedGet(ctx, "/vendors?"+v.Encode(), responseHandler); err != nil {
+		return nil, err
+	}
+	return vendors, nil
+}
+
 // GetVendor gets details about an existing vendor.
 func (c *Client) GetVendor(id string) (*Vendor, error) {
-	resp, err := c.get(context.TODO(), "/vendors/"+id)
+	return c.GetVendorWithContext(context.TODO(), id)
+}
+
+// GetVendorWithContext gets details about an existing vendor.
+func (c *Client) GetVendorWithContext(ctx context.Context, id string) (*Vendor, error) {
+	resp, err := c.get(ctx, "/vendors/"+id)
 	return getVendorFromResponse(c, resp, err)
 }
 
@@ -1,7 +1,10 @@
 package pagerduty
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"strconv"
 	"testing"
 )
 
@@ -66,3 +69,126 @@ func TestVendor_Get(t *testing.T) {
 	}
 	testEqual(t, want, res)
 }
+
+// Get Vendor with context
+func TestVendor_GetWithContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/vendors/PDATADOG", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Write([]byte(`{"vendor": {"id": "PDATADOG", "name": "Datadog", "logo_url": "https://example.com/dd.png", "integration_guide_url": "https://example.com/guide", "generic_service_type": "api"}}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.GetVendorWithContext(context.Background(), "PDATADOG")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Vendor{
+		APIObject: APIObject{
+			ID: "PDATADOG",
+		},
+		Name:                "Datadog",
+		LogoURL:             "https://example.com/dd.png",
+		IntegrationGuideURL: "https://example.com/guide",
+		GenericServiceType:  "api",
+	}
+	testEqual(t, want, res)
+}
+
+// ListVendorsPaginated
+func TestVendor_ListPaginated(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/vendors", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		offsetStr := r.URL.Query()["offset"][0]
+		offset, _ := strconv.ParseInt(offsetStr, 10, 32)
+
+		var more string
+		if offset == 0 {
+			more = "true"
+		} else {
+			more = "false"
+		}
+		resp := fmt.Sprintf(`{"vendors": [{"id": "%d", "name": "foo", "logo_url": "https://example.com/logo.png", "thumbnail_url": "https://example.com/thumb.png", "description": "bar", "website_url": "https://example.com", "integration_guide_url": "https://example.com/guide"}],
+                          "More": %s,
+                          "Offset": %d,
+                          "Limit": 1}`, offset, more, offset)
+		w.Write([]byte(resp))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+	var opts = ListVendorOptions{
+		APIListObject: APIListObject{Limit: 1},
+	}
+	res, err := client.ListVendorsPaginated(context.Background(), opts)
+
+	want := []Vendor{
+		{
+			APIObject:           APIObject{ID: "0"},
+			Name:                "foo",
+			LogoURL:             "https://example.com/logo.png",
+			ThumbnailURL:        "https://example.com/thumb.png",
+			Description:         "bar",
+			WebsiteURL:          "https://example.com",
+			IntegrationGuideURL: "https://example.com/guide",
+		},
+		{
+			APIObject:           APIObject{ID: "1"},
+			Name:                "foo",
+			LogoURL:             "https://example.com/logo.png",
+			ThumbnailURL:        "https://example.com/thumb.png",
+			Description:         "bar",
+			WebsiteURL:          "https://example.com",
+			IntegrationGuideURL: "https://example.com/guide",
+		},
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, want, res)
+}
+
+// ListVendors with ListAll ignores the Offset
+func TestVendor_ListAll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/vendors", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		q := r.URL.Query()
+		testEqual(t, "", q.Get("more"))
+		testEqual(t, "foo", q.Get("query"))
+
+		switch offsets := q["offset"]; {
+		case len(offsets) != 1:
+			t.Errorf("offsets = %v, want a single offset", offsets)
+		case offsets[0] == "0":
+			w.Write([]byte(`{"vendors": [{"id": "1"}], "offset": 0, "limit": 1, "more": true}`))
+		default:
+			w.Write([]byte(`{"vendors": [{"id": "2"}], "offset": 1, "limit": 1, "more": false}`))
+		}
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+	var opts = ListVendorOptions{
+		APIListObject: APIListObject{Limit: 1, Offset: 50, More: true, Total: 3},
+		Query:         "foo",
+		ListAll:       true,
+	}
+	res, err := client.ListVendorsWithContext(context.Background(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &ListVendorResponse{
+		Vendors: []Vendor{{APIObject: APIObject{ID: "1"}}, {APIObject: APIObject{ID: "2"}}},
+	}
+	testEqual(t, want, res)
+}
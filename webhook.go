@@ -1,11 +1,21 @@
 package pagerduty
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"strings"
 	"time"
 )
 
+// WebhookSignatureHeader is the header of V3 webhook deliveries carrying their
+// signatures, see VerifyWebhookSignature.
+const WebhookSignatureHeader = "X-PagerDuty-Signature"
+
 // IncidentDetails contains a representation of the incident associated with the action that caused this webhook message
 type IncidentDetails struct {
 	APIObject
@@ -41,7 +51,7 @@ type WebhookPayloadMessages struct {
 type WebhookPayload struct {
 	ID         string          `json:"id"`
 	Event      string          `json:"event"`
-	CreatedOn  time.Time       `json:"created_on`
+	CreatedOn  time.Time       `json:"created_on"`
 	Incident   IncidentDetails `json:"incident"`
 	LogEntries []LogEntry      `json:"log_entries"`
 }
@@ -54,3 +64,138 @@ func DecodeWebhook(r io.Reader) (*WebhookPayloadMessages, error) {
 	}
 	return &payload, nil
 }
+
+// VerifyWebhookSignature reports whether a V3 webhook delivery's payload was
+// signed with secret, the signing secret of its webhook subscription.
+// signatureHeader is the value of the X-PagerDuty-Signature header, a comma
+// separated list of v1=<hex HMAC-SHA256> signatures; while a secret is being
+// rotated it holds one signature per secret, and a match on any of them is
+// enough. An error is returned when the header has no v1 signature at all.
+func VerifyWebhookSignature(payload []byte, signatureHeader, secret string) (bool, error) {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	want := mac.Sum(nil)
+
+	var found bool
+	for _, sig := range strings.Split(signatureHeader, ",") {
+		sig = strings.TrimSpace(sig)
+		if !strings.HasPrefix(sig, "v1=") {
+			continue
+		}
+
+		found = true
+
+		got, err := hex.DecodeString(strings.TrimPrefix(sig, "v1="))
+		if err != nil {
+			continue
+		}
+
+		if hmac.Equal(got, want) {
+			return true, nil
+		}
+	}
+
+	if !found {
+		return false, errors.New("webhook signature header has no v1 signature")
+	}
+
+	return false, nil
+}
+
+// The values of WebhookEventV3.ResourceType whose data ParseWebhookPayload
+// decodes into a typed struct.
+const (
+	WebhookResourceTypeIncident = "incident"
+	WebhookResourceTypeService  = "service"
+)
+
+// WebhookPayloadV3 is the envelope of a V3 webhook delivery.
+type WebhookPayloadV3 struct {
+	Event WebhookEventV3 `json:"event"`
+}
+
+// WebhookEventV3 is a single event delivered by a V3 webhook subscription.
+type WebhookEventV3 struct {
+	ID           string     `json:"id"`
+	EventType    string     `json:"event_type"`
+	ResourceType string     `json:"resource_type"`
+	OccurredAt   time.Time  `json:"occurred_at"`
+	Agent        *APIObject `json:"agent"`
+
+	// RawData is the undecoded data of the event.
+	RawData json.RawMessage `json:"data"`
+
+	// Data is the data of the event decoded by ParseWebhookPayload: a
+	// *WebhookIncidentV3 for incident events, a *WebhookServiceV3 for service
+	// events, and RawData for anything else, such as an incident's notes.
+	Data interface{} `json:"-"`
+}
+
+// WebhookIncidentV3 is the incident carried by a V3 incident event.
+type WebhookIncidentV3 struct {
+	APIObject
+	Number           int               `json:"number"`
+	Title            string            `json:"title"`
+	Status           string            `json:"status"`
+	Urgency          string            `json:"urgency"`
+	IncidentKey      string            `json:"incident_key,omitempty"`
+	CreatedAt        time.Time         `json:"created_at"`
+	Service          APIObject         `json:"service"`
+	Assignees        []APIObject       `json:"assignees,omitempty"`
+	EscalationPolicy APIObject         `json:"escalation_policy"`
+	Teams            []APIObject       `json:"teams,omitempty"`
+	Priority         *APIObject        `json:"priority,omitempty"`
+	ConferenceBridge *ConferenceBridge `json:"conference_bridge,omitempty"`
+	ResolveReason    *string           `json:"resolve_reason,omitempty"`
+}
+
+// WebhookServiceV3 is the service carried by a V3 service event.
+type WebhookServiceV3 struct {
+	APIObject
+	Name             string      `json:"name,omitempty"`
+	Description      string      `json:"description,omitempty"`
+	Status           string      `json:"status,omitempty"`
+	EscalationPolicy *APIObject  `json:"escalation_policy,omitempty"`
+	Teams            []APIObject `json:"teams,omitempty"`
+}
+
+// ParseWebhookPayload decodes the body of a V3 webhook delivery, decoding the
+// event's data according to its resource type. Events of an unknown type
+// aren't an error, their Data is left as the raw JSON.
+func ParseWebhookPayload(body []byte) (*WebhookPayloadV3, error) {
+	var payload WebhookPayloadV3
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+
+	e := &payload.Event
+
+	// the data of some incident events, like incident.annotated, is a
+	// sub-resource of the incident rather than the incident itself
+	var kind struct {
+		Type string `json:"type"`
+	}
+	if len(e.RawData) > 0 {
+		if err := json.Unmarshal(e.RawData, &kind); err != nil {
+			return nil, fmt.Errorf("failed to decode %s event data: %w", e.EventType, err)
+		}
+	}
+
+	var data interface{}
+	switch {
+	case e.ResourceType == WebhookResourceTypeIncident && kind.Type == "incident":
+		data = &WebhookIncidentV3{}
+	case e.ResourceType == WebhookResourceTypeService && kind.Type == "service":
+		data = &WebhookServiceV3{}
+	default:
+		e.Data = e.RawData
+		return &payload, nil
+	}
+
+	if err := json.Unmarshal(e.RawData, data); err != nil {
+		return nil, fmt.Errorf("failed to decode %s event data: %w", e.EventType, err)
+	}
+	e.Data = data
+
+	return &payload, nil
+}
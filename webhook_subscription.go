@@ -0,0 +1,171 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-querystring/query"
+)
+
+// The values accepted by the Type field of WebhookSubscriptionFilter, and by
+// the FilterType field of ListWebhookSubscriptionsOptions.
+const (
+	WebhookSubscriptionFilterAccount = "account_reference"
+	WebhookSubscriptionFilterService = "service_reference"
+	WebhookSubscriptionFilterTeam    = "team_reference"
+)
+
+// WebhookSubscription is a V3 webhook subscription, which delivers the events
+// happening on the resources matching its filter to an HTTP endpoint.
+type WebhookSubscription struct {
+	ID             string                    `json:"id,omitempty"`
+	Type           string                    `json:"type"`
+	Active         bool                      `json:"active"`
+	DeliveryMethod WebhookSubscriptionMethod `json:"delivery_method"`
+	Description    string                    `json:"description,omitempty"`
+
+	// Events are the event types to deliver, such as "incident.triggered".
+	Events []string                  `json:"events"`
+	Filter WebhookSubscriptionFilter `json:"filter"`
+}
+
+// WebhookSubscriptionMethod is how a webhook subscription delivers its events.
+type WebhookSubscriptionMethod struct {
+	ID   string `json:"id,omitempty"`
+	Type string `json:"type"`
+	URL  string `json:"url"`
+
+	// Secret is used to sign the deliveries, see VerifyWebhookSignature. It's
+	// only returned when the subscription is created.
+	Secret              string                      `json:"secret,omitempty"`
+	TemporarilyDisabled bool                        `json:"temporarily_disabled,omitempty"`
+	CustomHeaders       []WebhookSubscriptionHeader `json:"custom_headers,omitempty"`
+}
+
+// WebhookSubscriptionHeader is a custom header sent with each delivery.
+type WebhookSubscriptionHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// WebhookSubscriptionFilter restricts the events of a webhook subscription to
+// a single service or team, or to the whole account.
+type WebhookSubscriptionFilter struct {
+	ID   string `json:"id,omitempty"`
+	Type string `json:"type"`
+}
+
+// ListWebhookSubscriptionsResponse is the data structure returned from calling
+// the ListWebhookSubscriptions API endpoint.
+type ListWebhookSubscriptionsResponse struct {
+	APIListObject
+	WebhookSubscriptions []WebhookSubscription `json:"webhook_subscriptions"`
+}
+
+// ListWebhookSubscriptionsOptions is the data structure used when calling the
+// ListWebhookSubscriptions API endpoint.
+type ListWebhookSubscriptionsOptions struct {
+	APIListObject
+	FilterType string `url:"filter_type,omitempty"`
+	FilterID   string `url:"filter_id,omitempty"`
+
+	// ListAll makes ListWebhookSubscriptions return every webhook subscription in
+	// a single response, with Limit as the page size. Offset is ignored, and
+	// ListAll isn't sent to the API.
+	ListAll bool `url:"-"`
+}
+
+// NewHTTPWebhookSubscription returns a webhook subscription delivering the
+// given events to url.
+func NewHTTPWebhookSubscription(url string, events []string, filter WebhookSubscriptionFilter) WebhookSubscription {
+	return WebhookSubscription{
+		Type:   "webhook_subscription",
+		Active: true,
+		DeliveryMethod: WebhookSubscriptionMethod{
+			Type: "http_delivery_method",
+			URL:  url,
+		},
+		Events: events,
+		Filter: filter,
+	}
+}
+
+// ListWebhookSubscriptions lists the account's webhook subscriptions,
+// optionally filtered by the resource they apply to.
+func (c *Client) ListWebhookSubscriptions(ctx context.Context, o ListWebhookSubscriptionsOptions) (*ListWebhookSubscriptionsResponse, error) {
+	if o.ListAll {
+		var all ListWebhookSubscriptionsResponse
+		responseHandler := func(response *http.Response) (APIListObject, error) {
+			var result ListWebhookSubscriptionsResponse
+			if err := c.decodeJSON(response, &result); err != nil {
+				return APIListObject{}, err
+			}
+			all.WebhookSubscriptions = append(all.WebhookSubscriptions, result.WebhookSubscriptions...)
+			return result.APIListObject, nil
+		}
+		if err := c.pagedGetAll(ctx, "/webhook_subscriptions", o, responseHandler); err != nil {
+			return nil, err
+		}
+		return &all, nil
+	}
+
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.get(ctx, "/webhook_subscriptions?"+v.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListWebhookSubscriptionsResponse
+	return &result, c.decodeJSON(resp, &result)
+}
+
+// GetWebhookSubscription gets details about an existing webhook subscription.
+func (c *Client) GetWebhookSubscription(ctx context.Context, id string) (*WebhookSubscription, error) {
+	resp, err := c.get(ctx, "/webhook_subscriptions/"+id)
+	return getWebhookSubscriptionFromResponse(c, resp, err)
+}
+
+// CreateWebhookSubscription creates a new webhook subscription. The returned
+// subscription's DeliveryMethod.Secret is the only time the signing secret is
+// available.
+func (c *Client) CreateWebhookSubscription(ctx context.Context, s WebhookSubscription) (*WebhookSubscription, error) {
+	resp, err := c.post(ctx, "/webhook_subscriptions", wrapBody("webhook_subscription", s), nil)
+	return getWebhookSubscriptionFromResponse(c, resp, err)
+}
+
+// UpdateWebhookSubscription updates an existing webhook subscription.
+func (c *Client) UpdateWebhookSubscription(ctx context.Context, s WebhookSubscription) (*WebhookSubscription, error) {
+	resp, err := c.put(ctx, "/webhook_subscriptions/"+s.ID, wrapBody("webhook_subscription", s), nil)
+	return getWebhookSubscriptionFromResponse(c, resp, err)
+}
+
+// DeleteWebhookSubscription deletes a webhook subscription.
+func (c *Client) DeleteWebhookSubscription(ctx context.Context, id string) error {
+	_, err := c.delete(ctx, "/webhook_subscriptions/"+id)
+	return err
+}
+
+func getWebhookSubscriptionFromResponse(c *Client, resp *http.Response, err error) (*WebhookSubscription, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	var target map[string]WebhookSubscription
+	if dErr := c.decodeJSON(resp, &target); dErr != nil {
+		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
+	}
+
+	const rootNode = "webhook_subscription"
+
+	t, nodeOK := target[rootNode]
+	if !nodeOK {
+		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
+	}
+
+	return &t, nil
+}
@@ -0,0 +1,114 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// List Webhook Subscriptions
+func TestWebhookSubscription_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/webhook_subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, WebhookSubscriptionFilterService, r.URL.Query().Get("filter_type"))
+		testEqual(t, "PSVC1", r.URL.Query().Get("filter_id"))
+		w.Write([]byte(`{"webhook_subscriptions": [{"id": "PWSUB1", "type": "webhook_subscription", "active": true, "events": ["incident.triggered"], "filter": {"id": "PSVC1", "type": "service_reference"}, "delivery_method": {"type": "http_delivery_method", "url": "https://example.com/hook"}}]}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.ListWebhookSubscriptions(context.Background(), ListWebhookSubscriptionsOptions{FilterType: WebhookSubscriptionFilterService, FilterID: "PSVC1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []WebhookSubscription{
+		{
+			ID:     "PWSUB1",
+			Type:   "webhook_subscription",
+			Active: true,
+			Events: []string{"incident.triggered"},
+			Filter: WebhookSubscriptionFilter{ID: "PSVC1", Type: WebhookSubscriptionFilterService},
+			DeliveryMethod: WebhookSubscriptionMethod{
+				Type: "http_delivery_method",
+				URL:  "https://example.com/hook",
+			},
+		},
+	}
+	testEqual(t, want, res.WebhookSubscriptions)
+}
+
+// Create Webhook Subscription
+func TestWebhookSubscription_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/webhook_subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body map[string]WebhookSubscription
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		s := body["webhook_subscription"]
+		testEqual(t, "http_delivery_method", s.DeliveryMethod.Type)
+		testEqual(t, []WebhookSubscriptionHeader{{Name: "X-Env", Value: "prod"}}, s.DeliveryMethod.CustomHeaders)
+		testEqual(t, WebhookSubscriptionFilter{Type: WebhookSubscriptionFilterAccount}, s.Filter)
+
+		w.Write([]byte(`{"webhook_subscription": {"id": "PWSUB1", "type": "webhook_subscription", "active": true, "events": ["incident.triggered"], "filter": {"type": "account_reference"}, "delivery_method": {"id": "PDM1", "type": "http_delivery_method", "url": "https://example.com/hook", "secret": "s3cret"}}}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	s := NewHTTPWebhookSubscription("https://example.com/hook", []string{"incident.triggered"}, WebhookSubscriptionFilter{Type: WebhookSubscriptionFilterAccount})
+	s.DeliveryMethod.CustomHeaders = []WebhookSubscriptionHeader{{Name: "X-Env", Value: "prod"}}
+
+	res, err := client.CreateWebhookSubscription(context.Background(), s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "PWSUB1", res.ID)
+	testEqual(t, "s3cret", res.DeliveryMethod.Secret)
+}
+
+// Update Webhook Subscription
+func TestWebhookSubscription_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/webhook_subscriptions/PWSUB1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		w.Write([]byte(`{"webhook_subscription": {"id": "PWSUB1", "active": false}}`))
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	res, err := client.UpdateWebhookSubscription(context.Background(), WebhookSubscription{ID: "PWSUB1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, &WebhookSubscription{ID: "PWSUB1"}, res)
+}
+
+// Delete Webhook Subscription
+func TestWebhookSubscription_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/webhook_subscriptions/PWSUB1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	var client = &Client{apiEndpoint: server.URL, authToken: "foo", HTTPClient: defaultHTTPClient}
+
+	if err := client.DeleteWebhookSubscription(context.Background(), "PWSUB1"); err != nil {
+		t.Fatal(err)
+	}
+}
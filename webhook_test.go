@@ -1,6 +1,10 @@
 package pagerduty
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"strings"
 	"testing"
 )
@@ -40,3 +44,91 @@ func TestWebhook_DecodeWebhook(t *testing.T) {
 		t.Fatal("Expected 1 Assignment")
 	}
 }
+
+// VerifyWebhookSignature
+func TestWebhook_VerifyWebhookSignature(t *testing.T) {
+	payload := []byte(`{"event":{"id":"01ABC","event_type":"incident.triggered"}}`)
+
+	sign := func(secret string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(payload)
+		return "v1=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		want   bool
+		err    string
+	}{
+		{name: "single", header: sign("new"), want: true},
+		{name: "rotation", header: sign("old") + ", " + sign("new"), want: true},
+		{name: "mismatch", header: sign("old"), want: false},
+		{name: "malformed hex", header: "v1=zz," + sign("new"), want: true},
+		{name: "no v1", header: "v0=abc", err: "no v1 signature"},
+		{name: "empty", header: "", err: "no v1 signature"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := VerifyWebhookSignature(payload, tt.header, "new")
+			if !testErrCheck(t, "VerifyWebhookSignature()", tt.err, err) {
+				return
+			}
+			testEqual(t, tt.want, got)
+		})
+	}
+}
+
+// ParseWebhookPayload
+func TestWebhook_ParseWebhookPayload(t *testing.T) {
+	t.Run("incident", func(t *testing.T) {
+		body := []byte(`{"event": {"id": "01ABC", "event_type": "incident.triggered", "resource_type": "incident", "occurred_at": "2025-02-03T10:00:00Z", "agent": {"id": "PUSER1", "type": "user_reference"}, "data": {"id": "PINC1", "type": "incident", "number": 42, "title": "Disk full", "status": "triggered", "urgency": "high", "service": {"id": "PSVC1", "type": "service_reference"}}}}`)
+
+		p, err := ParseWebhookPayload(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testEqual(t, "incident.triggered", p.Event.EventType)
+		testEqual(t, &APIObject{ID: "PUSER1", Type: "user_reference"}, p.Event.Agent)
+
+		want := &WebhookIncidentV3{
+			APIObject: APIObject{ID: "PINC1", Type: "incident"},
+			Number:    42,
+			Title:     "Disk full",
+			Status:    "triggered",
+			Urgency:   "high",
+			Service:   APIObject{ID: "PSVC1", Type: "service_reference"},
+		}
+		testEqual(t, want, p.Event.Data)
+	})
+
+	t.Run("service", func(t *testing.T) {
+		body := []byte(`{"event": {"id": "01ABD", "event_type": "service.updated", "resource_type": "service", "data": {"id": "PSVC1", "type": "service", "name": "Checkout"}}}`)
+
+		p, err := ParseWebhookPayload(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testEqual(t, &WebhookServiceV3{APIObject: APIObject{ID: "PSVC1", Type: "service"}, Name: "Checkout"}, p.Event.Data)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		data := `{"id": "PNOTE1", "type": "incident_note", "content": "looking"}`
+		body := []byte(`{"event": {"id": "01ABE", "event_type": "incident.annotated", "resource_type": "incident", "data": ` + data + `}}`)
+
+		p, err := ParseWebhookPayload(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testEqual(t, json.RawMessage(data), p.Event.Data)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseWebhookPayload([]byte(`{"event": {"resource_type": "incident", "data": {"type": "incident", "number": "x"}}}`))
+		testErrCheck(t, "ParseWebhookPayload()", "failed to decode", err)
+	})
+}